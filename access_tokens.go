@@ -0,0 +1,81 @@
+package netlify
+
+import (
+	"path"
+)
+
+// AccessToken is an access token for the netlify API
+type AccessToken struct {
+	Id          string `json:"id"`
+	AccessToken string `json:"access_token"`
+	UserId      string `json:"user_id"`
+	UserEmail   string `json:"user_email"`
+
+	// Scopes limit what the token can be used for. A token without
+	// scopes has full access to the account of the user.
+	Scopes []string `json:"scopes"`
+
+	CreatedAt Timestamp `json:"created_at"`
+
+	client *Client
+}
+
+// AccessTokensService is used to access all AccessToken related API methods
+type AccessTokensService struct {
+	client *Client
+}
+
+// Attributes for AccessTokens.Create. Set UserId for an existing sub-user,
+// or Email and Uid to create the sub-user along with the token.
+type AccessTokenAttributes struct {
+	UserId string   `json:"user_id,omitempty"`
+	Email  string   `json:"email,omitempty"`
+	Uid    string   `json:"uid,omitempty"`
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// Create a new access token for a sub-user. The AccessToken field of the
+// result is only returned once, so store it right away.
+func (s *AccessTokensService) Create(attributes *AccessTokenAttributes) (*AccessToken, *Response, error) {
+	token := &AccessToken{client: s.client}
+
+	reqOptions := &RequestOptions{JsonBody: attributes}
+
+	resp, err := s.client.Request("POST", "/access_tokens", reqOptions, token)
+
+	return token, resp, err
+}
+
+// List all access tokens you've created. Takes ListOptions to control pagination.
+func (s *AccessTokensService) List(options *ListOptions) ([]AccessToken, *Response, error) {
+	tokens := new([]AccessToken)
+
+	reqOptions := &RequestOptions{QueryParams: options.toQueryParamsMap()}
+
+	resp, err := s.client.Request("GET", "/access_tokens", reqOptions, tokens)
+
+	for i := range *tokens {
+		(*tokens)[i].client = s.client
+	}
+
+	return *tokens, resp, err
+}
+
+// Revoke an access token by its ID. Requests with the token will fail afterwards.
+func (s *AccessTokensService) Revoke(id string) (*Response, error) {
+	token := &AccessToken{Id: id, client: s.client}
+	return token.Revoke()
+}
+
+func (token *AccessToken) apiPath() string {
+	return path.Join("/access_tokens", token.Id)
+}
+
+// Revoke the access token
+func (token *AccessToken) Revoke() (*Response, error) {
+	resp, err := token.client.Request("DELETE", token.apiPath(), nil, nil)
+	if resp != nil && resp.Body != nil {
+		resp.Body.Close()
+	}
+	return resp, err
+}
@@ -0,0 +1,56 @@
+package netlify
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestAccessTokensService_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/access_tokens", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		buf := new(bytes.Buffer)
+		buf.ReadFrom(r.Body)
+
+		expected := `{"email":"client@example.com","uid":"client-42","scopes":["sites:read"]}`
+		if expected != strings.TrimSpace(buf.String()) {
+			t.Errorf("Expected JSON: %v\nGot JSON: %v", expected, buf.String())
+		}
+
+		fmt.Fprint(w, `{"id":"my-token","access_token":"secret","user_email":"client@example.com","scopes":["sites:read"]}`)
+	})
+
+	token, _, err := client.AccessTokens.Create(&AccessTokenAttributes{Email: "client@example.com", Uid: "client-42", Scopes: []string{"sites:read"}})
+	if err != nil {
+		t.Errorf("AccessTokens.Create returned an error: %v", err)
+	}
+
+	if token.Id != "my-token" || token.AccessToken != "secret" {
+		t.Errorf("Unexpected token returned from AccessTokens.Create: %v", token)
+	}
+}
+
+func TestAccessTokensService_Revoke(t *testing.T) {
+	setup()
+	defer teardown()
+
+	revoked := false
+	mux.HandleFunc("/api/v1/access_tokens/my-token", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		revoked = true
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if _, err := client.AccessTokens.Revoke("my-token"); err != nil {
+		t.Errorf("AccessTokens.Revoke returned an error: %v", err)
+	}
+	if !revoked {
+		t.Errorf("Expected the token to be revoked")
+	}
+}
@@ -0,0 +1,166 @@
+package netlify
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"path"
+
+	oauth "golang.org/x/oauth2"
+)
+
+// BuildHook is a URL that triggers a new build of a site when it receives a POST request
+type BuildHook struct {
+	Id     string `json:"id"`
+	SiteId string `json:"site_id"`
+
+	// These fields can be updated through the API
+	Title  string `json:"title"`
+	Branch string `json:"branch"`
+
+	Url string `json:"url"`
+
+	CreatedAt Timestamp `json:"created_at"`
+
+	client *Client
+}
+
+// BuildHooksService is used to access all BuildHook related API methods
+type BuildHooksService struct {
+	site   *Site
+	client *Client
+}
+
+// Attributes for BuildHooks.Create
+type BuildHookAttributes struct {
+	Title  string `json:"title"`
+	Branch string `json:"branch,omitempty"`
+}
+
+func (s *BuildHooksService) apiPath() string {
+	return path.Join(s.site.apiPath(), "build_hooks")
+}
+
+// Create a new named build hook. Builds triggered by the hook will build
+// the given branch, or the site's default branch when none is set.
+func (s *BuildHooksService) Create(attributes *BuildHookAttributes) (*BuildHook, *Response, error) {
+	hook := &BuildHook{SiteId: s.site.Id, client: s.client}
+
+	reqOptions := &RequestOptions{JsonBody: attributes}
+
+	resp, err := s.client.Request("POST", s.apiPath(), reqOptions, hook)
+
+	return hook, resp, err
+}
+
+// List all build hooks for the site
+func (s *BuildHooksService) List() ([]BuildHook, *Response, error) {
+	hooks := new([]BuildHook)
+
+	resp, err := s.client.Request("GET", s.apiPath(), nil, hooks)
+
+	for i := range *hooks {
+		(*hooks)[i].SiteId = s.site.Id
+		(*hooks)[i].client = s.client
+	}
+
+	return *hooks, resp, err
+}
+
+// Get a specific build hook.
+func (s *BuildHooksService) Get(id string) (*BuildHook, *Response, error) {
+	hook := &BuildHook{Id: id, SiteId: s.site.Id, client: s.client}
+	resp, err := hook.Reload()
+
+	return hook, resp, err
+}
+
+func (hook *BuildHook) apiPath() string {
+	return path.Join("/sites", hook.SiteId, "build_hooks", hook.Id)
+}
+
+// Reload a build hook from the API
+func (hook *BuildHook) Reload() (*Response, error) {
+	if hook.Id == "" {
+		return nil, errors.New("Cannot fetch build hook without an ID")
+	}
+	return hook.client.Request("GET", hook.apiPath(), nil, hook)
+}
+
+// Update will update the title and branch of the build hook. The API
+// doesn't return the updated hook, so it's reloaded afterwards.
+func (hook *BuildHook) Update() (*Response, error) {
+	options := &RequestOptions{JsonBody: &BuildHookAttributes{Title: hook.Title, Branch: hook.Branch}}
+
+	resp, err := hook.client.Request("PUT", hook.apiPath(), options, nil)
+	if resp != nil && resp.Body != nil {
+		resp.Body.Close()
+	}
+	if err != nil {
+		return resp, err
+	}
+
+	return hook.Reload()
+}
+
+// Destroy deletes a build hook permanently
+func (hook *BuildHook) Destroy() (*Response, error) {
+	resp, err := hook.client.Request("DELETE", hook.apiPath(), nil, nil)
+	if resp != nil && resp.Body != nil {
+		resp.Body.Close()
+	}
+	return resp, err
+}
+
+// Trigger a build through the hook. The title is optional and will show up
+// as the deploy message in the netlify UI.
+func (hook *BuildHook) Trigger(title string) (*Response, error) {
+	return hook.client.TriggerBuildHook(hook.Url, title)
+}
+
+// TriggerBuildHook sends a POST request to a build hook URL. Build hook URLs
+// don't require authentication, so this also works for unauthenticated clients.
+// The access token of the client is never sent to the hook URL.
+func (c *Client) TriggerBuildHook(hookUrl, title string) (*Response, error) {
+	u, err := url.Parse(hookUrl)
+	if err != nil {
+		return nil, err
+	}
+	if title != "" {
+		params := u.Query()
+		params.Set("trigger_title", title)
+		u.RawQuery = params.Encode()
+	}
+
+	req, err := http.NewRequest("POST", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("User-Agent", c.UserAgent)
+
+	httpResponse, err := c.unauthenticatedClient().Do(req)
+	resp := newResponse(httpResponse)
+	if err != nil {
+		return resp, err
+	}
+	defer httpResponse.Body.Close()
+
+	return resp, checkResponse(httpResponse)
+}
+
+// unauthenticatedClient returns an HTTP client with the timeout and transport
+// of the API client, but without the token source that authenticates it
+func (c *Client) unauthenticatedClient() *http.Client {
+	if c.client == nil {
+		if c.config != nil && c.config.RequestTimeout > 0 {
+			return &http.Client{Timeout: c.config.RequestTimeout}
+		}
+		return http.DefaultClient
+	}
+
+	transport := c.client.Transport
+	if oauthTransport, ok := transport.(*oauth.Transport); ok {
+		transport = oauthTransport.Base
+	}
+	return &http.Client{Transport: transport, Timeout: c.client.Timeout}
+}
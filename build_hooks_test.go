@@ -0,0 +1,90 @@
+package netlify
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestBuildHooksService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/sites/my-site/build_hooks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"id":"first","title":"CMS","branch":"master"},{"id":"second","title":"Docs"}]`)
+	})
+
+	site := &Site{Id: "my-site"}
+	site.setClient(client)
+
+	hooks, _, err := site.BuildHooks.List()
+	if err != nil {
+		t.Errorf("BuildHooks.List returned an error: %v", err)
+	}
+
+	if len(hooks) != 2 || hooks[0].Title != "CMS" || hooks[1].apiPath() != "/sites/my-site/build_hooks/second" {
+		t.Errorf("Unexpected build hooks: %v", hooks)
+	}
+}
+
+func TestBuildHook_Trigger(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/build_hooks/my-hook", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testFormValues(t, r, values{"trigger_title": "Content update"})
+	})
+
+	hook := &BuildHook{Id: "my-hook", Url: server.URL + "/build_hooks/my-hook", client: client}
+	if _, err := hook.Trigger("Content update"); err != nil {
+		t.Errorf("BuildHook.Trigger returned an error: %v", err)
+	}
+}
+
+func TestBuildHook_Trigger_Without_Token(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/build_hooks/my-hook", func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			t.Errorf("Build hooks should not receive the access token, got %v", auth)
+		}
+	})
+
+	authenticated := NewClient(&Config{AccessToken: "secret", BaseUrl: server.URL})
+	if _, err := authenticated.TriggerBuildHook(server.URL+"/build_hooks/my-hook", ""); err != nil {
+		t.Errorf("TriggerBuildHook returned an error: %v", err)
+	}
+}
+
+func TestBuildHook_Update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	updated := false
+	mux.HandleFunc("/api/v1/sites/my-site/build_hooks/my-hook", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			updated = true
+			w.WriteHeader(http.StatusNoContent)
+		case "GET":
+			if !updated {
+				t.Errorf("Expected the hook to be reloaded after the update")
+			}
+			fmt.Fprint(w, `{"id":"my-hook","title":"CMS","branch":"staging","url":"https://api.netlify.com/build_hooks/my-hook"}`)
+		default:
+			t.Errorf("Unexpected request method %v", r.Method)
+		}
+	})
+
+	hook := &BuildHook{Id: "my-hook", SiteId: "my-site", Title: "CMS", Branch: "staging", client: client}
+	if _, err := hook.Update(); err != nil {
+		t.Errorf("BuildHook.Update returned an error: %v", err)
+	}
+
+	if hook.Url != "https://api.netlify.com/build_hooks/my-hook" {
+		t.Errorf("Expected the updated hook to be decoded, got %v", hook)
+	}
+}
@@ -0,0 +1,112 @@
+package netlify
+
+import (
+	"context"
+	"errors"
+	"path"
+)
+
+// Build represents a continuous deployment build of a site
+type Build struct {
+	Id       string `json:"id"`
+	DeployId string `json:"deploy_id"`
+	Sha      string `json:"sha"`
+
+	// Done is true once the build has finished, successfully or not
+	Done bool `json:"done"`
+
+	// Cause of the failure if the build failed
+	Error string `json:"error"`
+
+	CreatedAt Timestamp `json:"created_at"`
+
+	client *Client
+}
+
+// BuildsService is used to access all Build related API methods
+type BuildsService struct {
+	site   *Site
+	client *Client
+}
+
+// Attributes for Builds.Create
+type BuildOptions struct {
+	// Clear the build cache before building
+	ClearCache bool `json:"clear_cache,omitempty"`
+
+	// Branch to build. Defaults to the branch configured for the site
+	Branch string `json:"branch,omitempty"`
+}
+
+func (s *BuildsService) apiPath() string {
+	return path.Join(s.site.apiPath(), "builds")
+}
+
+// Create triggers a new build of the site's repository
+func (s *BuildsService) Create(buildOptions *BuildOptions) (*Build, *Response, error) {
+	build := &Build{client: s.client}
+
+	reqOptions := &RequestOptions{JsonBody: buildOptions}
+
+	resp, err := s.client.Request("POST", s.apiPath(), reqOptions, build)
+
+	return build, resp, err
+}
+
+// List all builds for the site. Takes ListOptions to control pagination.
+func (s *BuildsService) List(options *ListOptions) ([]Build, *Response, error) {
+	builds := new([]Build)
+
+	reqOptions := &RequestOptions{QueryParams: options.toQueryParamsMap()}
+
+	resp, err := s.client.Request("GET", s.apiPath(), reqOptions, builds)
+
+	for i := range *builds {
+		(*builds)[i].client = s.client
+	}
+
+	return *builds, resp, err
+}
+
+// Get a specific build.
+func (s *BuildsService) Get(id string) (*Build, *Response, error) {
+	build := &Build{Id: id, client: s.client}
+	resp, err := build.Reload()
+
+	return build, resp, err
+}
+
+func (build *Build) apiPath() string {
+	return path.Join("/builds", build.Id)
+}
+
+// Reload a build from the API
+func (build *Build) Reload() (*Response, error) {
+	if build.Id == "" {
+		return nil, errors.New("Cannot fetch build without an ID")
+	}
+	return build.client.Request("GET", build.apiPath(), nil, build)
+}
+
+// WaitForDeploy waits for the build to finish and returns the deploy it produced
+func (build *Build) WaitForDeploy(ctx context.Context) (*Deploy, error) {
+	err := poll(ctx, func() (bool, error) {
+		if _, err := build.Reload(); err != nil {
+			return true, err
+		}
+		return build.Done, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if build.Error != "" {
+		return nil, errors.New("Build failed: " + build.Error)
+	}
+	if build.DeployId == "" {
+		return nil, errors.New("Build finished without creating a deploy")
+	}
+
+	deploy, _, err := build.client.Deploys.Get(build.DeployId)
+	return deploy, err
+}
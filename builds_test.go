@@ -0,0 +1,76 @@
+package netlify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildsService_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/sites/my-site/builds", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		buf := new(bytes.Buffer)
+		buf.ReadFrom(r.Body)
+
+		expected := `{"clear_cache":true,"branch":"staging"}`
+		if expected != strings.TrimSpace(buf.String()) {
+			t.Errorf("Expected JSON: %v\nGot JSON: %v", expected, buf.String())
+		}
+
+		fmt.Fprint(w, `{"id":"my-build"}`)
+	})
+
+	site := &Site{Id: "my-site", client: client}
+	site.Builds = &BuildsService{client: client, site: site}
+
+	build, _, err := site.Builds.Create(&BuildOptions{ClearCache: true, Branch: "staging"})
+	if err != nil {
+		t.Errorf("Builds.Create returned an error: %v", err)
+	}
+
+	if build.Id != "my-build" {
+		t.Errorf("Expected Builds.Create to return my-build, returned %v", build.Id)
+	}
+}
+
+func TestBuild_WaitForDeploy(t *testing.T) {
+	setup()
+	defer teardown()
+
+	deployPollInterval = time.Millisecond
+	defer func() { deployPollInterval = time.Second }()
+
+	polls := 0
+	mux.HandleFunc("/api/v1/builds/my-build", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		polls++
+		if polls < 2 {
+			fmt.Fprint(w, `{"id":"my-build","done":false}`)
+		} else {
+			fmt.Fprint(w, `{"id":"my-build","done":true,"deploy_id":"my-deploy"}`)
+		}
+	})
+
+	mux.HandleFunc("/api/v1/deploys/my-deploy", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":"my-deploy","state":"ready"}`)
+	})
+
+	build := &Build{Id: "my-build", client: client}
+	deploy, err := build.WaitForDeploy(context.Background())
+	if err != nil {
+		t.Errorf("Build.WaitForDeploy returned an error: %v", err)
+	}
+
+	if deploy.Id != "my-deploy" {
+		t.Errorf("Expected Build.WaitForDeploy to return my-deploy, returned %v", deploy.Id)
+	}
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Build holds the [build] settings of netlify.toml, or the overrides for a
+// deploy context in [context.<name>]
+type Build struct {
+	// Directory to change to before building, relative to the repo root
+	Base string `toml:"base"`
+
+	// Directory to publish, relative to Base
+	Publish string `toml:"publish"`
+
+	// Build command
+	Command string `toml:"command"`
+
+	// Directory with functions to deploy, relative to Base
+	Functions string `toml:"functions"`
+
+	Environment map[string]string `toml:"environment"`
+}
+
+// LoadToml reads the netlify.toml file at the root of a repository
+func LoadToml(root string) (*Config, error) {
+	file, err := os.Open(filepath.Join(root, TomlFile))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ParseToml(file)
+}
+
+// BuildFor returns the build settings for a deploy context, like
+// "production" or "deploy-preview", with the overrides for the context
+// applied on top of [build]. When branch is set, the overrides for
+// [context.<branch>] are applied last.
+func (c *Config) BuildFor(context, branch string) Build {
+	build := c.Build.merge(Build{})
+	if override, ok := c.Context[context]; ok {
+		build = build.merge(override)
+	}
+	if override, ok := c.Context[branch]; ok && branch != "" && branch != context {
+		build = build.merge(override)
+	}
+	return build
+}
+
+// PublishDir returns the path of the directory to publish, relative to the
+// repository root
+func (b Build) PublishDir() string {
+	return filepath.Join(b.Base, b.Publish)
+}
+
+// FunctionsDir returns the path of the functions directory relative to the
+// repository root, or an empty string when there are no functions
+func (b Build) FunctionsDir() string {
+	if b.Functions == "" {
+		return ""
+	}
+	return filepath.Join(b.Base, b.Functions)
+}
+
+func (b Build) merge(override Build) Build {
+	if override.Base != "" {
+		b.Base = override.Base
+	}
+	if override.Publish != "" {
+		b.Publish = override.Publish
+	}
+	if override.Command != "" {
+		b.Command = override.Command
+	}
+	if override.Functions != "" {
+		b.Functions = override.Functions
+	}
+
+	environment := map[string]string{}
+	for key, value := range b.Environment {
+		environment[key] = value
+	}
+	for key, value := range override.Environment {
+		environment[key] = value
+	}
+	b.Environment = environment
+
+	return b
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testToml = `
+[build]
+  base = "site"
+  publish = "dist"
+  command = "npm run build"
+  functions = "functions"
+  environment = {NODE_ENV = "production", API_URL = "https://api.example.com"}
+
+[context.deploy-preview]
+  command = "npm run build:preview"
+  environment = {API_URL = "https://staging-api.example.com"}
+
+[context.docs]
+  publish = "docs"
+`
+
+func TestConfig_BuildFor(t *testing.T) {
+	cfg, err := ParseToml(strings.NewReader(testToml))
+	if err != nil {
+		t.Fatalf("ParseToml returned an error: %v", err)
+	}
+
+	production := cfg.BuildFor("production", "")
+	if production.PublishDir() != "site/dist" || production.FunctionsDir() != "site/functions" || production.Command != "npm run build" {
+		t.Errorf("Unexpected production build settings: %+v", production)
+	}
+
+	preview := cfg.BuildFor("deploy-preview", "docs")
+	expected := Build{
+		Base:      "site",
+		Publish:   "docs",
+		Command:   "npm run build:preview",
+		Functions: "functions",
+		Environment: map[string]string{
+			"NODE_ENV": "production",
+			"API_URL":  "https://staging-api.example.com",
+		},
+	}
+	if !reflect.DeepEqual(preview, expected) {
+		t.Errorf("Expected deploy preview build settings %+v, got %+v", expected, preview)
+	}
+
+	if cfg.Build.Environment["API_URL"] != "https://api.example.com" {
+		t.Errorf("BuildFor should not modify the [build] settings")
+	}
+}
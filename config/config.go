@@ -0,0 +1,135 @@
+/*
+Package config parses and validates the files that configure how netlify
+serves a site: _redirects, _headers and the [[redirects]] and [[headers]]
+sections of netlify.toml.
+
+	cfg, err := config.LoadDir("/path/to/site-dir")
+	if err != nil {
+	  return err
+	}
+	if err := cfg.Validate(); err != nil {
+	  // err is a config.Errors with the file and line of each problem
+	}
+*/
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Names of the configuration files
+const (
+	RedirectsFile = "_redirects"
+	HeadersFile   = "_headers"
+	TomlFile      = "netlify.toml"
+)
+
+// Config holds the settings from all configuration files of a site
+type Config struct {
+	Build   Build            `toml:"build"`
+	Context map[string]Build `toml:"context"`
+
+	Redirects []Redirect `toml:"redirects"`
+	Headers   []Header   `toml:"headers"`
+}
+
+// Error is a problem at a specific line of a configuration file. Rules from
+// netlify.toml have no line numbers, their position in the file is used instead.
+type Error struct {
+	File    string
+	Line    int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Message)
+}
+
+// Errors is a list of problems found while parsing or validating
+type Errors []*Error
+
+func (e Errors) Error() string {
+	messages := []string{}
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "\n")
+}
+
+func (e Errors) errOrNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// LoadDir reads the _redirects, _headers and netlify.toml files of a
+// directory. Missing files are skipped. Rules from netlify.toml come after
+// the rules from _redirects and _headers, just like when netlify processes
+// a deploy.
+func LoadDir(dir string) (*Config, error) {
+	return LoadRepo(dir, dir)
+}
+
+// LoadRepo reads the _redirects and _headers files of the publish directory
+// and the netlify.toml file at the root of the repository, like netlify does
+// for a deploy built from a repository. Missing files are skipped.
+func LoadRepo(publishDir, root string) (*Config, error) {
+	cfg := &Config{}
+
+	parsers := []struct {
+		dir   string
+		name  string
+		parse func(io.Reader) error
+	}{
+		{publishDir, RedirectsFile, func(r io.Reader) (err error) {
+			cfg.Redirects, err = ParseRedirects(r)
+			return
+		}},
+		{publishDir, HeadersFile, func(r io.Reader) (err error) {
+			cfg.Headers, err = ParseHeaders(r)
+			return
+		}},
+		{root, TomlFile, func(r io.Reader) error {
+			toml, err := ParseToml(r)
+			if err == nil {
+				cfg.Redirects = append(cfg.Redirects, toml.Redirects...)
+				cfg.Headers = append(cfg.Headers, toml.Headers...)
+			}
+			return err
+		}},
+	}
+
+	for _, parser := range parsers {
+		file, err := os.Open(filepath.Join(parser.dir, parser.name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		err = parser.parse(file)
+		file.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return cfg, nil
+}
+
+// Validate checks all rules and returns Errors listing every problem found
+func (c *Config) Validate() error {
+	errors := Errors{}
+	for i := range c.Redirects {
+		errors = append(errors, c.Redirects[i].validate()...)
+	}
+	for i := range c.Headers {
+		errors = append(errors, c.Headers[i].validate()...)
+	}
+	return errors.errOrNil()
+}
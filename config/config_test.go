@@ -0,0 +1,74 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "netlify-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ioutil.WriteFile(filepath.Join(dir, RedirectsFile), []byte("/old /new\n"), 0644)
+	ioutil.WriteFile(filepath.Join(dir, TomlFile), []byte(`
+[[redirects]]
+  from = "/search"
+  to = "/results/:q"
+  status = 200
+  force = true
+  query = {q = ":q"}
+  conditions = {Country = ["us"]}
+
+[[headers]]
+  for = "/*"
+  [headers.values]
+    X-Frame-Options = "DENY"
+`), 0644)
+
+	cfg, err := LoadDir(dir)
+	if err != nil {
+		t.Fatalf("LoadDir returned an error: %v", err)
+	}
+
+	if len(cfg.Redirects) != 2 || cfg.Redirects[0].From != "/old" || cfg.Redirects[1].From != "/search" {
+		t.Fatalf("Expected redirects from _redirects followed by netlify.toml, got %v", cfg.Redirects)
+	}
+	if redirect := cfg.Redirects[1]; !redirect.Force || redirect.Query["q"] != ":q" || redirect.Conditions["Country"][0] != "us" {
+		t.Errorf("Unexpected redirect from netlify.toml: %v", redirect)
+	}
+	if len(cfg.Headers) != 1 || cfg.Headers[0].Values["X-Frame-Options"] != "DENY" {
+		t.Errorf("Unexpected headers: %v", cfg.Headers)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected configuration to be valid, got %v", err)
+	}
+}
+
+func TestLoadRepo(t *testing.T) {
+	root, err := ioutil.TempDir("", "netlify-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	publishDir := filepath.Join(root, "public")
+	os.MkdirAll(publishDir, 0755)
+	ioutil.WriteFile(filepath.Join(publishDir, RedirectsFile), []byte("/old /new\n"), 0644)
+	ioutil.WriteFile(filepath.Join(publishDir, TomlFile), []byte("[[redirects]]\n  from = \"/ignored\"\n  to = \"/\"\n"), 0644)
+	ioutil.WriteFile(filepath.Join(root, TomlFile), []byte("[[redirects]]\n  from = \"/search\"\n  to = \"/results\"\n"), 0644)
+
+	cfg, err := LoadRepo(publishDir, root)
+	if err != nil {
+		t.Fatalf("LoadRepo returned an error: %v", err)
+	}
+
+	if len(cfg.Redirects) != 2 || cfg.Redirects[0].From != "/old" || cfg.Redirects[1].From != "/search" {
+		t.Errorf("Expected redirects from the publish dir and the netlify.toml in the root, got %v", cfg.Redirects)
+	}
+}
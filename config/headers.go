@@ -0,0 +1,105 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Header is a set of custom headers for the paths matching For
+type Header struct {
+	For string `toml:"for"`
+
+	// Header names mapping to their values. Repeated headers in _headers
+	// are joined with a comma.
+	Values map[string]string `toml:"values"`
+
+	// File and line the rule was read from
+	File string `toml:"-"`
+	Line int    `toml:"-"`
+}
+
+// ParseHeaders parses a _headers file. A line without indentation starts a
+// rule for a path, indented lines below it set the headers:
+//
+//	/assets/*
+//	  Cache-Control: public, max-age=31536000
+func ParseHeaders(r io.Reader) ([]Header, error) {
+	headers := []Header{}
+	errors := Errors{}
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		raw := scanner.Text()
+		line := strings.TrimSpace(raw)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if raw[0] != ' ' && raw[0] != '\t' {
+			headers = append(headers, Header{For: line, Values: map[string]string{}, File: HeadersFile, Line: n})
+			continue
+		}
+
+		if len(headers) == 0 {
+			errors = append(errors, &Error{File: HeadersFile, Line: n, Message: "Header without a path"})
+			continue
+		}
+
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			errors = append(errors, &Error{File: HeadersFile, Line: n, Message: fmt.Sprintf("Expected Name: value, got %q", line)})
+			continue
+		}
+
+		header := &headers[len(headers)-1]
+		name, value := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		if existing, ok := header.Values[name]; ok {
+			value = existing + ", " + value
+		}
+		header.Values[name] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return headers, errors.errOrNil()
+}
+
+func (h *Header) validate() Errors {
+	errors := Errors{}
+	fail := func(format string, args ...interface{}) {
+		errors = append(errors, &Error{File: h.File, Line: h.Line, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if !isPathOrURL(h.For) {
+		fail("Header path must be a path or an absolute URL, got %q", h.For)
+	}
+	if len(h.Values) == 0 {
+		fail("No headers set for %s", h.For)
+	}
+	for name, value := range h.Values {
+		if !validHeaderName(name) {
+			fail("Invalid header name %q for %s", name, h.For)
+		}
+		if value == "" {
+			fail("Empty value for header %s for %s", name, h.For)
+		}
+	}
+
+	return errors
+}
+
+// validHeaderName checks that name is an RFC 7230 token
+func validHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		if c <= ' ' || c >= 0x7f || strings.ContainsRune(`"(),/:;<=>?@[\]{}`, c) {
+			return false
+		}
+	}
+	return true
+}
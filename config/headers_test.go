@@ -0,0 +1,49 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseHeaders(t *testing.T) {
+	file := `
+/*
+  X-Frame-Options: DENY
+  Link: </style.css>; rel=preload; as=style
+  Link: </app.js>; rel=preload; as=script
+
+# Cache fingerprinted assets forever
+/assets/*
+  Cache-Control: public, max-age=31536000
+`
+
+	headers, err := ParseHeaders(strings.NewReader(file))
+	if err != nil {
+		t.Fatalf("ParseHeaders returned an error: %v", err)
+	}
+
+	expected := []Header{
+		{For: "/*", Values: map[string]string{
+			"X-Frame-Options": "DENY",
+			"Link":            "</style.css>; rel=preload; as=style, </app.js>; rel=preload; as=script",
+		}, File: HeadersFile, Line: 2},
+		{For: "/assets/*", Values: map[string]string{"Cache-Control": "public, max-age=31536000"}, File: HeadersFile, Line: 8},
+	}
+	if !reflect.DeepEqual(headers, expected) {
+		t.Errorf("Expected headers:\n%v\ngot:\n%v", expected, headers)
+	}
+}
+
+func TestHeader_Validate(t *testing.T) {
+	headers, err := ParseHeaders(strings.NewReader("/*\n  Bad Header: value\n/empty\n"))
+	if err != nil {
+		t.Fatalf("ParseHeaders returned an error: %v", err)
+	}
+
+	err = (&Config{Headers: headers}).Validate()
+	errors, ok := err.(Errors)
+	if !ok || len(errors) != 2 || errors[0].Line != 1 || errors[1].Line != 3 {
+		t.Errorf("Expected errors for line 1 and 3, got %v", err)
+	}
+}
@@ -0,0 +1,211 @@
+package config
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Request headers Match reads conditions from. Netlify sets these from the
+// geolocation and identity of the visitor, tests can set them directly.
+const (
+	CountryHeader = "X-Country"
+	RoleHeader    = "X-Role"
+)
+
+// Result is the outcome of matching a request against redirect rules
+type Result struct {
+	// The rule that matched
+	Redirect *Redirect
+
+	// Destination with placeholders and the splat filled in
+	To string
+
+	Status int
+}
+
+// Rewrite is true when the request is served from To without a redirect
+func (r *Result) Rewrite() bool {
+	return r.Status == 200
+}
+
+// Proxy is true when the request is proxied to another server
+func (r *Result) Proxy() bool {
+	return r.Rewrite() && !strings.HasPrefix(r.To, "/")
+}
+
+// Matcher evaluates redirect rules the way netlify does
+type Matcher struct {
+	Rules []Redirect
+
+	// FileExists reports whether the deploy has a file at a path. Rules
+	// that aren't forced are skipped when a file exists, so the file
+	// shadows the rule. When nil, no files exist.
+	FileExists func(path string) bool
+}
+
+// Match returns the first of the rules matching the request, or nil when
+// no rule matches. It assumes the deploy has no files shadowing the rules,
+// use a Matcher to control that.
+func Match(rules []Redirect, r *http.Request) *Result {
+	return (&Matcher{Rules: rules}).Match(r)
+}
+
+// Match returns the first rule matching the request, or nil when no rule matches
+func (m *Matcher) Match(r *http.Request) *Result {
+	for i := range m.Rules {
+		rule := &m.Rules[i]
+		if !rule.Force && m.FileExists != nil && m.FileExists(r.URL.Path) {
+			continue
+		}
+
+		values, ok := rule.match(r)
+		if !ok {
+			continue
+		}
+
+		to := expandPlaceholders(rule.To, values)
+		if len(rule.Query) == 0 && r.URL.RawQuery != "" && !strings.Contains(to, "?") {
+			to += "?" + r.URL.RawQuery
+		}
+		return &Result{Redirect: rule, To: to, Status: rule.StatusCode()}
+	}
+	return nil
+}
+
+// match checks if the rule applies to the request and returns the values
+// of its placeholders
+func (rule *Redirect) match(r *http.Request) (map[string]string, bool) {
+	if host := ruleHost(rule.From); host != "" && !strings.EqualFold(host, stripPort(r.Host)) {
+		return nil, false
+	}
+
+	values, ok := matchPath(rule.fromPath(), r.URL.Path)
+	if !ok {
+		return nil, false
+	}
+
+	if !matchQuery(rule.Query, r.URL.Query(), values) {
+		return nil, false
+	}
+
+	for key, allowed := range rule.Conditions {
+		if !matchCondition(key, allowed, r) {
+			return nil, false
+		}
+	}
+
+	return values, true
+}
+
+func ruleHost(from string) string {
+	u, err := url.Parse(from)
+	if err != nil || u.Scheme == "" {
+		return ""
+	}
+	return stripPort(u.Host)
+}
+
+func stripPort(host string) string {
+	if i := strings.LastIndex(host, ":"); i >= 0 && !strings.Contains(host[i:], "]") {
+		return host[:i]
+	}
+	return host
+}
+
+func matchPath(pattern, path string) (map[string]string, bool) {
+	values := map[string]string{}
+	patternSegments := splitPath(pattern)
+	pathSegments := splitPath(path)
+
+	for i, segment := range patternSegments {
+		if segment == "*" && i == len(patternSegments)-1 {
+			if i < len(pathSegments) {
+				values["splat"] = strings.Join(pathSegments[i:], "/")
+			} else {
+				values["splat"] = ""
+			}
+			return values, true
+		}
+		if i >= len(pathSegments) {
+			return nil, false
+		}
+		if strings.HasPrefix(segment, ":") {
+			values[segment[1:]] = pathSegments[i]
+		} else if segment != pathSegments[i] {
+			return nil, false
+		}
+	}
+
+	return values, len(patternSegments) == len(pathSegments)
+}
+
+// splitPath splits a path into its segments, ignoring a trailing slash
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return []string{}
+	}
+	return strings.Split(path, "/")
+}
+
+func matchQuery(expected map[string]string, query url.Values, values map[string]string) bool {
+	for key, value := range expected {
+		actual, ok := query[key]
+		if !ok || len(actual) == 0 {
+			return false
+		}
+		if strings.HasPrefix(value, ":") {
+			values[value[1:]] = actual[0]
+		} else if value != actual[0] {
+			return false
+		}
+	}
+	return true
+}
+
+func matchCondition(key string, allowed []string, r *http.Request) bool {
+	var actual []string
+	switch key {
+	case "Country":
+		actual = []string{r.Header.Get(CountryHeader)}
+	case "Language":
+		actual = acceptedLanguages(r.Header.Get("Accept-Language"))
+	case "Role":
+		actual = strings.Split(r.Header.Get(RoleHeader), ",")
+	case "Cookie":
+		for _, cookie := range r.Cookies() {
+			actual = append(actual, cookie.Name)
+		}
+	}
+
+	for _, value := range actual {
+		for _, a := range allowed {
+			if strings.EqualFold(strings.TrimSpace(value), a) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// acceptedLanguages returns the primary language tags from an Accept-Language header
+func acceptedLanguages(header string) []string {
+	languages := []string{}
+	for _, part := range strings.Split(header, ",") {
+		tag := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+		if tag != "" && tag != "*" {
+			languages = append(languages, strings.SplitN(tag, "-", 2)[0])
+		}
+	}
+	return languages
+}
+
+func expandPlaceholders(to string, values map[string]string) string {
+	return placeholderPattern.ReplaceAllStringFunc(to, func(placeholder string) string {
+		if value, ok := values[placeholder[1:]]; ok {
+			return value
+		}
+		return placeholder
+	})
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testRedirects = `
+/blog/*            /news/:splat         301
+/store id=:id      /products/:id        302
+/app/*             /index.html          200
+/api/*             https://api.example.com/:splat 200!
+/                  /au                  302 Country=au
+/                  /fr                  302 Language=fr
+http://old.example.com/* https://example.com/:splat 301!
+/posts/:year/:slug /:year/:slug
+`
+
+func TestMatch(t *testing.T) {
+	rules, err := ParseRedirects(strings.NewReader(testRedirects))
+	if err != nil {
+		t.Fatalf("ParseRedirects returned an error: %v", err)
+	}
+
+	cases := []struct {
+		url     string
+		headers map[string]string
+		to      string
+		status  int
+	}{
+		{"/blog/2017/hello", nil, "/news/2017/hello", 301},
+		{"/blog", nil, "/news/", 301},
+		{"/store?id=42", nil, "/products/42", 302},
+		{"/store", nil, "", 0},
+		{"/app/settings/profile", nil, "/index.html", 200},
+		{"/api/users?page=2", nil, "https://api.example.com/users?page=2", 200},
+		{"/", map[string]string{CountryHeader: "AU"}, "/au", 302},
+		{"/", map[string]string{"Accept-Language": "fr-CA,fr;q=0.9,en;q=0.8"}, "/fr", 302},
+		{"/", map[string]string{"Accept-Language": "en-US"}, "", 0},
+		{"http://old.example.com/about/", nil, "https://example.com/about", 301},
+		{"/posts/2017/hello-world/", nil, "/2017/hello-world", 301},
+		{"/posts/2017", nil, "", 0},
+	}
+
+	for _, c := range cases {
+		r := httptest.NewRequest("GET", c.url, nil)
+		for key, value := range c.headers {
+			r.Header.Set(key, value)
+		}
+
+		result := Match(rules, r)
+		if c.status == 0 {
+			if result != nil {
+				t.Errorf("Expected %s not to match, got %s", c.url, result.To)
+			}
+			continue
+		}
+		if result == nil {
+			t.Errorf("Expected %s to match %s, got no match", c.url, c.to)
+			continue
+		}
+		if result.To != c.to || result.Status != c.status {
+			t.Errorf("Expected %s to match %s %d, got %s %d", c.url, c.to, c.status, result.To, result.Status)
+		}
+	}
+}
+
+func TestMatcher_Shadowing(t *testing.T) {
+	rules, _ := ParseRedirects(strings.NewReader("/app/* /index.html 200\n/api/* /.netlify/functions/:splat 200!\n"))
+	matcher := &Matcher{Rules: rules, FileExists: func(path string) bool {
+		return path == "/app/logo.png" || path == "/api/cached.json"
+	}}
+
+	if result := matcher.Match(httptest.NewRequest("GET", "/app/logo.png", nil)); result != nil {
+		t.Errorf("Expected existing file to shadow rule, got %s", result.To)
+	}
+
+	result := matcher.Match(httptest.NewRequest("GET", "/api/cached.json", nil))
+	if result == nil || !result.Rewrite() || result.Proxy() || result.To != "/.netlify/functions/cached.json" {
+		t.Errorf("Expected forced rule to apply despite existing file, got %v", result)
+	}
+}
@@ -0,0 +1,220 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Keys supported in Redirect.Conditions
+var redirectConditions = map[string]bool{
+	"Country":  true,
+	"Language": true,
+	"Role":     true,
+	"Cookie":   true,
+}
+
+var redirectStatuses = map[int]bool{
+	200: true, 301: true, 302: true, 303: true, 307: true, 308: true,
+	404: true, 410: true, 451: true,
+}
+
+var placeholderPattern = regexp.MustCompile(`:[A-Za-z_][A-Za-z0-9_]*`)
+
+// Redirect is a single redirect or rewrite rule
+type Redirect struct {
+	From string `toml:"from"`
+	To   string `toml:"to"`
+
+	// HTTP status, a 200 status is a rewrite. Defaults to 301.
+	Status int `toml:"status"`
+
+	// Force the rule even if a file exists at the path
+	Force bool `toml:"force"`
+
+	// Query parameters that must be present, values starting with a colon
+	// are placeholders that can be used in To
+	Query map[string]string `toml:"query"`
+
+	// Conditions like Country and Language, mapping to the allowed values
+	Conditions map[string][]string `toml:"conditions"`
+
+	// Headers to send with proxied requests
+	Headers map[string]string `toml:"headers"`
+
+	// File and line the rule was read from
+	File string `toml:"-"`
+	Line int    `toml:"-"`
+}
+
+// ParseRedirects parses a _redirects file. Each line is a rule in the form
+//
+//	/from [key=value...] /to [status[!]] [Condition=value,value...]
+func ParseRedirects(r io.Reader) ([]Redirect, error) {
+	redirects := []Redirect{}
+	errors := Errors{}
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		redirect, err := parseRedirectLine(strings.Fields(line))
+		if err != nil {
+			errors = append(errors, &Error{File: RedirectsFile, Line: n, Message: err.Error()})
+			continue
+		}
+		redirect.File, redirect.Line = RedirectsFile, n
+		redirects = append(redirects, redirect)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return redirects, errors.errOrNil()
+}
+
+func parseRedirectLine(fields []string) (Redirect, error) {
+	redirect := Redirect{From: fields[0]}
+	fields = fields[1:]
+
+	for len(fields) > 0 && isKeyValue(fields[0]) {
+		if redirect.Query == nil {
+			redirect.Query = map[string]string{}
+		}
+		parts := strings.SplitN(fields[0], "=", 2)
+		redirect.Query[parts[0]] = parts[1]
+		fields = fields[1:]
+	}
+
+	if len(fields) == 0 {
+		return redirect, fmt.Errorf("Missing destination for %s", redirect.From)
+	}
+	redirect.To = fields[0]
+	fields = fields[1:]
+
+	if len(fields) > 0 && !isKeyValue(fields[0]) {
+		status := fields[0]
+		if strings.HasSuffix(status, "!") {
+			redirect.Force = true
+			status = strings.TrimSuffix(status, "!")
+		}
+		code, err := strconv.Atoi(status)
+		if err != nil {
+			return redirect, fmt.Errorf("Invalid status %s", fields[0])
+		}
+		redirect.Status = code
+		fields = fields[1:]
+	}
+
+	for _, field := range fields {
+		if !isKeyValue(field) {
+			return redirect, fmt.Errorf("Unexpected %s after the status", field)
+		}
+		if redirect.Conditions == nil {
+			redirect.Conditions = map[string][]string{}
+		}
+		parts := strings.SplitN(field, "=", 2)
+		redirect.Conditions[parts[0]] = strings.Split(parts[1], ",")
+	}
+
+	return redirect, nil
+}
+
+func isKeyValue(field string) bool {
+	i := strings.Index(field, "=")
+	return i > 0 && !strings.Contains(field[:i], "/")
+}
+
+// StatusCode returns the status of the rule, defaulting to 301
+func (r *Redirect) StatusCode() int {
+	if r.Status == 0 {
+		return 301
+	}
+	return r.Status
+}
+
+// Placeholders returns the names of the placeholders defined by the path
+// and query of From, including "splat" for a trailing *
+func (r *Redirect) Placeholders() map[string]bool {
+	placeholders := map[string]bool{}
+	for _, segment := range strings.Split(r.fromPath(), "/") {
+		if strings.HasPrefix(segment, ":") {
+			placeholders[segment[1:]] = true
+		}
+	}
+	for _, value := range r.Query {
+		if strings.HasPrefix(value, ":") {
+			placeholders[value[1:]] = true
+		}
+	}
+	if strings.HasSuffix(r.From, "*") {
+		placeholders["splat"] = true
+	}
+	return placeholders
+}
+
+// fromPath strips the scheme and host of rules for a specific domain
+func (r *Redirect) fromPath() string {
+	if i := strings.Index(r.From, "://"); i >= 0 {
+		rest := r.From[i+3:]
+		if j := strings.Index(rest, "/"); j >= 0 {
+			return rest[j:]
+		}
+		return "/"
+	}
+	return r.From
+}
+
+func (r *Redirect) validate() Errors {
+	errors := Errors{}
+	fail := func(format string, args ...interface{}) {
+		errors = append(errors, &Error{File: r.File, Line: r.Line, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if !isPathOrURL(r.From) {
+		fail("From must be a path or an absolute URL, got %q", r.From)
+	}
+	if !isPathOrURL(r.To) {
+		fail("To must be a path or an absolute URL, got %q", r.To)
+	}
+	if !redirectStatuses[r.StatusCode()] {
+		fail("Unsupported status %d", r.Status)
+	}
+	if i := strings.Index(r.From, "*"); i >= 0 && i != len(r.From)-1 {
+		fail("A splat (*) is only allowed at the end of %s", r.From)
+	}
+
+	placeholders := r.Placeholders()
+	for _, placeholder := range placeholderPattern.FindAllString(r.To, -1) {
+		if !placeholders[placeholder[1:]] {
+			if placeholder == ":splat" {
+				fail("%s uses :splat but %s doesn't end with *", r.To, r.From)
+			} else {
+				fail("%s uses %s which isn't defined in %s", r.To, placeholder, r.From)
+			}
+		}
+	}
+
+	for key, values := range r.Conditions {
+		if !redirectConditions[key] {
+			fail("Unknown condition %s", key)
+		}
+		for _, value := range values {
+			if value == "" {
+				fail("Empty value for condition %s", key)
+			}
+		}
+	}
+
+	return errors
+}
+
+func isPathOrURL(s string) bool {
+	return strings.HasPrefix(s, "/") || strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
+}
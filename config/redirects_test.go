@@ -0,0 +1,74 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseRedirects(t *testing.T) {
+	file := `
+# Redirects from the old site
+/home              /                     301
+/news/:year/:month /blog/:year/:month
+/store id=:id      /products/:id         302!
+/api/*             https://api.example.com/:splat 200
+/                  /anz                  302  Country=au,nz Language=en
+`
+
+	redirects, err := ParseRedirects(strings.NewReader(file))
+	if err != nil {
+		t.Fatalf("ParseRedirects returned an error: %v", err)
+	}
+
+	expected := []Redirect{
+		{From: "/home", To: "/", Status: 301, File: RedirectsFile, Line: 3},
+		{From: "/news/:year/:month", To: "/blog/:year/:month", File: RedirectsFile, Line: 4},
+		{From: "/store", To: "/products/:id", Status: 302, Force: true, Query: map[string]string{"id": ":id"}, File: RedirectsFile, Line: 5},
+		{From: "/api/*", To: "https://api.example.com/:splat", Status: 200, File: RedirectsFile, Line: 6},
+		{From: "/", To: "/anz", Status: 302, Conditions: map[string][]string{"Country": {"au", "nz"}, "Language": {"en"}}, File: RedirectsFile, Line: 7},
+	}
+	if !reflect.DeepEqual(redirects, expected) {
+		t.Errorf("Expected redirects:\n%v\ngot:\n%v", expected, redirects)
+	}
+
+	if err := (&Config{Redirects: redirects}).Validate(); err != nil {
+		t.Errorf("Expected redirects to be valid, got %v", err)
+	}
+}
+
+func TestRedirect_Validate(t *testing.T) {
+	cases := []struct {
+		line    string
+		message string
+	}{
+		{"/old /new 999", "Unsupported status 999"},
+		{"/old/*/page /new", "A splat (*) is only allowed at the end of /old/*/page"},
+		{"/old /new/:splat", "/new/:splat uses :splat but /old doesn't end with *"},
+		{"/posts/:id /blog/:slug", "/blog/:slug uses :slug which isn't defined in /posts/:id"},
+		{"/ /uk 302 Region=gb", "Unknown condition Region"},
+		{"old new", `From must be a path or an absolute URL, got "old"`},
+	}
+
+	for _, c := range cases {
+		redirects, err := ParseRedirects(strings.NewReader(c.line))
+		if err != nil {
+			t.Errorf("ParseRedirects(%q) returned an error: %v", c.line, err)
+			continue
+		}
+
+		err = (&Config{Redirects: redirects}).Validate()
+		if expected := "_redirects:1: " + c.message; err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected validating %q to fail with %q, got %v", c.line, expected, err)
+		}
+	}
+}
+
+func TestParseRedirects_Errors(t *testing.T) {
+	_, err := ParseRedirects(strings.NewReader("/ok /fine\n/missing-destination\n/bad /status abc\n"))
+
+	errors, ok := err.(Errors)
+	if !ok || len(errors) != 2 || errors[0].Line != 2 || errors[1].Line != 3 {
+		t.Errorf("Expected errors for line 2 and 3, got %v", err)
+	}
+}
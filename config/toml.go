@@ -0,0 +1,25 @@
+package config
+
+import (
+	"io"
+
+	"github.com/BurntSushi/toml"
+)
+
+// ParseToml parses the [build], [context.*], [[redirects]] and [[headers]]
+// sections of a netlify.toml file
+func ParseToml(r io.Reader) (*Config, error) {
+	cfg := &Config{}
+	if _, err := toml.DecodeReader(r, cfg); err != nil {
+		return nil, err
+	}
+
+	for i := range cfg.Redirects {
+		cfg.Redirects[i].File, cfg.Redirects[i].Line = TomlFile, i+1
+	}
+	for i := range cfg.Headers {
+		cfg.Headers[i].File, cfg.Headers[i].Line = TomlFile, i+1
+	}
+
+	return cfg, nil
+}
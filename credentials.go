@@ -0,0 +1,107 @@
+package netlify
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	oauth "golang.org/x/oauth2"
+)
+
+// AuthTokenEnvVar is the environment variable an access token is read from
+const AuthTokenEnvVar = "NETLIFY_AUTH_TOKEN"
+
+// ErrNotAuthenticated is returned by requests from a client without credentials
+var ErrNotAuthenticated = errors.New("Client has not been authenticated, set Config.AccessToken or Config.TokenSource or use NewAuthenticatedClient")
+
+// ErrNoCredentials is returned when the credential chain finds no credentials
+var ErrNoCredentials = errors.New("No netlify credentials found: set Config.AccessToken or Config.TokenSource, " +
+	"set the " + AuthTokenEnvVar + " environment variable or log in with the netlify CLI")
+
+// NewAuthenticatedClient returns a new netlify API client with credentials
+// from the first of these that is set:
+//
+//   - Config.TokenSource or Config.AccessToken
+//   - The NETLIFY_AUTH_TOKEN environment variable
+//   - The access token of the current user of the netlify CLI
+//
+// It returns ErrNoCredentials when none of them is set.
+func NewAuthenticatedClient(config *Config) (*Client, error) {
+	ts, err := FindTokenSource(config)
+	if err != nil {
+		return nil, err
+	}
+	return NewClientWithTokenSource(config, ts), nil
+}
+
+// FindTokenSource runs the credential chain described in NewAuthenticatedClient
+func FindTokenSource(config *Config) (oauth.TokenSource, error) {
+	if config.TokenSource != nil {
+		return config.TokenSource, nil
+	}
+	if config.AccessToken != "" {
+		return config, nil
+	}
+	if token := os.Getenv(AuthTokenEnvVar); token != "" {
+		return oauth.StaticTokenSource(&oauth.Token{AccessToken: token}), nil
+	}
+
+	for _, path := range cliConfigPaths() {
+		token, err := readCLIToken(path)
+		if err != nil {
+			return nil, err
+		}
+		if token != "" {
+			return oauth.StaticTokenSource(&oauth.Token{AccessToken: token}), nil
+		}
+	}
+
+	return nil, ErrNoCredentials
+}
+
+// cliConfigPaths lists the locations the netlify CLI has stored its config in
+func cliConfigPaths() []string {
+	paths := []string{}
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		paths = append(paths, filepath.Join(xdg, "netlify", "config.json"))
+	}
+	if home := os.Getenv("HOME"); home != "" {
+		paths = append(paths,
+			filepath.Join(home, ".config", "netlify", "config.json"),
+			filepath.Join(home, "Library", "Preferences", "netlify", "config.json"),
+			filepath.Join(home, ".netlify", "config.json"),
+		)
+	}
+	return paths
+}
+
+type cliConfig struct {
+	UserId string `json:"userId"`
+	Users  map[string]struct {
+		Auth struct {
+			Token string `json:"token"`
+		} `json:"auth"`
+	} `json:"users"`
+}
+
+// readCLIToken reads the token of the current user from a netlify CLI
+// config file. A missing file or a config without a logged in user isn't
+// an error.
+func readCLIToken(path string) (string, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	config := &cliConfig{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return "", errors.New("Invalid netlify CLI config " + path + ": " + err.Error())
+	}
+
+	return config.Users[config.UserId].Auth.Token, nil
+}
@@ -0,0 +1,55 @@
+package netlify
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindTokenSource(t *testing.T) {
+	home, err := ioutil.TempDir("", "netlify-home")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(home)
+
+	for _, key := range []string{"HOME", "XDG_CONFIG_HOME", AuthTokenEnvVar} {
+		defer os.Setenv(key, os.Getenv(key))
+	}
+	os.Setenv("HOME", home)
+	os.Unsetenv("XDG_CONFIG_HOME")
+	os.Unsetenv(AuthTokenEnvVar)
+
+	if _, err := FindTokenSource(&Config{}); err != ErrNoCredentials {
+		t.Errorf("Expected ErrNoCredentials without any credentials, got %v", err)
+	}
+
+	os.MkdirAll(filepath.Join(home, ".netlify"), 0755)
+	ioutil.WriteFile(filepath.Join(home, ".netlify", "config.json"),
+		[]byte(`{"userId":"me","users":{"me":{"auth":{"token":"cli-token"}}}}`), 0600)
+
+	cases := []struct {
+		config   *Config
+		env      string
+		expected string
+	}{
+		{&Config{AccessToken: "explicit-token"}, "env-token", "explicit-token"},
+		{&Config{}, "env-token", "env-token"},
+		{&Config{}, "", "cli-token"},
+	}
+
+	for _, c := range cases {
+		os.Setenv(AuthTokenEnvVar, c.env)
+
+		ts, err := FindTokenSource(c.config)
+		if err != nil {
+			t.Errorf("FindTokenSource returned an error: %v", err)
+			continue
+		}
+		token, _ := ts.Token()
+		if token.AccessToken != c.expected {
+			t.Errorf("Expected token %v, got %v", c.expected, token.AccessToken)
+		}
+	}
+}
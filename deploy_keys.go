@@ -1,11 +1,18 @@
 package netlify
 
+import (
+	"errors"
+	"path"
+)
+
 // DeployKey for use with continuous deployment setups
 type DeployKey struct {
 	Id        string `json:"id"`
 	PublicKey string `json:"public_key"`
 
 	CreatedAt Timestamp `json:"created_at"`
+
+	client *Client
 }
 
 // DeployKeysService is used to access all DeployKey related API methods
@@ -16,9 +23,52 @@ type DeployKeysService struct {
 
 // Create a new deploy key for use with continuous deployment
 func (d *DeployKeysService) Create() (*DeployKey, *Response, error) {
-	deployKey := &DeployKey{}
+	deployKey := &DeployKey{client: d.client}
 
 	resp, err := d.client.Request("POST", "/deploy_keys", &RequestOptions{}, deployKey)
 
 	return deployKey, resp, err
 }
+
+// List all deploy keys you have access to
+func (d *DeployKeysService) List() ([]DeployKey, *Response, error) {
+	deployKeys := new([]DeployKey)
+
+	resp, err := d.client.Request("GET", "/deploy_keys", nil, deployKeys)
+
+	for i := range *deployKeys {
+		(*deployKeys)[i].client = d.client
+	}
+
+	return *deployKeys, resp, err
+}
+
+// Get a specific deploy key.
+func (d *DeployKeysService) Get(id string) (*DeployKey, *Response, error) {
+	deployKey := &DeployKey{Id: id, client: d.client}
+	resp, err := deployKey.Reload()
+
+	return deployKey, resp, err
+}
+
+func (deployKey *DeployKey) apiPath() string {
+	return path.Join("/deploy_keys", deployKey.Id)
+}
+
+// Reload a deploy key from the API
+func (deployKey *DeployKey) Reload() (*Response, error) {
+	if deployKey.Id == "" {
+		return nil, errors.New("Cannot fetch deploy key without an ID")
+	}
+	return deployKey.client.Request("GET", deployKey.apiPath(), nil, deployKey)
+}
+
+// Destroy deletes a deploy key permanently. Sites using the key won't be
+// able to access their repository anymore.
+func (deployKey *DeployKey) Destroy() (*Response, error) {
+	resp, err := deployKey.client.Request("DELETE", deployKey.apiPath(), nil, nil)
+	if resp != nil && resp.Body != nil {
+		resp.Body.Close()
+	}
+	return resp, err
+}
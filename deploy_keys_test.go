@@ -0,0 +1,60 @@
+package netlify
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestDeployKeysService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/deploy_keys", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"id":"first","public_key":"ssh-rsa AAAA"},{"id":"second"}]`)
+	})
+
+	deployKeys, _, err := client.DeployKeys.List()
+	if err != nil {
+		t.Errorf("DeployKeys.List returned an error: %v", err)
+	}
+
+	if len(deployKeys) != 2 || deployKeys[0].PublicKey != "ssh-rsa AAAA" {
+		t.Errorf("Unexpected deploy keys: %v", deployKeys)
+	}
+}
+
+func TestSite_ContinuousDeployment_With_DeployKey(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/sites/my-site", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		params := map[string]*RepoOptions{}
+		json.NewDecoder(r.Body).Decode(&params)
+		if params["repo"] == nil || params["repo"].DeployKeyID != "my-key" {
+			t.Errorf("Expected deploy_key_id my-key, got %v", params["repo"])
+		}
+
+		fmt.Fprint(w, `{"id":"my-site"}`)
+	})
+
+	site := &Site{Id: "my-site"}
+	site.setClient(client)
+
+	repoOptions := &RepoOptions{Repo: "netlify/netlify-home", Provider: "github", DeployKey: &DeployKey{Id: "my-key"}}
+	_, err := site.ContinuousDeployment(repoOptions)
+	if err != nil {
+		t.Errorf("Site.ContinuousDeployment returned an error: %v", err)
+	}
+	if repoOptions.DeployKeyID != "" {
+		t.Errorf("Expected the repo options not to be changed, got %v", repoOptions)
+	}
+
+	if _, err := site.ContinuousDeployment(nil); err == nil {
+		t.Errorf("Expected Site.ContinuousDeployment to reject nil repo options")
+	}
+}
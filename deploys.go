@@ -1,9 +1,12 @@
 package netlify
 
 import (
+	"context"
 	"crypto/sha1"
 	"encoding/hex"
 	"errors"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"net/url"
 	"os"
@@ -15,11 +18,15 @@ import (
 
 	"github.com/cenkalti/backoff"
 	"github.com/sirupsen/logrus"
+
+	"github.com/netlify/netlify-go/config"
 )
 
 const MaxFilesForSyncDeploy = 1000
 const PreProcessingTimeout = time.Minute * 5
 
+var deployPollInterval = 1 * time.Second
+
 // Deploy represents a specific deploy of a site
 type Deploy struct {
 	Id     string `json:"id"`
@@ -45,10 +52,22 @@ type Deploy struct {
 	Branch    string `json:"branch,omitempty"`
 	CommitRef string `json:"commit_ref,omitempty"`
 
+	// Deploy message, framework and metadata set with DeployOptions
+	Title     string            `json:"title,omitempty"`
+	Framework string            `json:"framework,omitempty"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+
 	client *Client
 	logger *logrus.Entry
 }
 
+// LogEntry is a single line from the build log of a deploy
+type LogEntry struct {
+	Message string    `json:"message"`
+	Section string    `json:"section"`
+	Time    Timestamp `json:"ts"`
+}
+
 func (d Deploy) log() *logrus.Entry {
 	if d.logger == nil {
 		d.logger = d.client.log.WithFields(logrus.Fields{
@@ -94,6 +113,34 @@ type deployFiles struct {
 	Async     bool               `json:"async"`
 	Branch    string             `json:"branch,omitempty"`
 	CommitRef string             `json:"commit_ref,omitempty"`
+	Title     string             `json:"title,omitempty"`
+	Framework string             `json:"framework,omitempty"`
+	Metadata  map[string]string  `json:"metadata,omitempty"`
+}
+
+// DeployOptions are the settings for deploying a directory
+type DeployOptions struct {
+	// Git branch and commit the deploy is made from
+	Branch    string
+	CommitRef string
+
+	// Deploy message shown in the deploy list
+	Title string
+
+	// Framework the site is built with, like "hugo" or "gatsby"
+	Framework string
+
+	// Arbitrary metadata stored with the deploy
+	Metadata map[string]string
+
+	// Root of the repository the deployed directory was built from. When the
+	// client validates config files, netlify.toml is read from the root
+	// instead of from the deployed directory.
+	Root string
+
+	// Create a draft deploy with CreateWithOptions. Draft deploys won't
+	// affect the active deploy for a site.
+	Draft bool
 }
 
 func (s *DeploysService) apiPath() string {
@@ -117,7 +164,110 @@ func (s *DeploysService) CreateDraft(dirOrZip string) (*Deploy, *Response, error
 	return s.create(dirOrZip, true)
 }
 
+// CreateWithOptions a new deploy of a directory with DeployOptions, to set
+// the git info, title, framework or metadata of the deploy, or to make it a
+// draft. The options can be nil.
+func (s *DeploysService) CreateWithOptions(dir string, options *DeployOptions) (*Deploy, *Response, error) {
+	if options == nil {
+		options = &DeployOptions{}
+	}
+	if err := s.client.validateConfigFiles(dir, options.Root); err != nil {
+		return nil, nil, err
+	}
+
+	deploy, resp, err := s.createEmpty(options.Draft)
+	if err != nil {
+		return deploy, resp, err
+	}
+
+	resp, err = deploy.DeployDirWithOptions(dir, options)
+	return deploy, resp, err
+}
+
+// CreateFromRepo deploys a site from the root of its repository. The publish
+// directory is read from the netlify.toml in the root, with the overrides for
+// the deploy context and for the branch applied. Deploys for any other context
+// than "production" are created as drafts.
+//
+// When branch is empty, the branch is read with ReadGitInfo if root is in a
+// git repository. The commit of the deploy is read with ReadGitInfo as well,
+// and the first line of the commit message is used as the title.
+//
+// Functions can't be deployed yet, so an error is returned when netlify.toml
+// sets a functions directory. The build environment isn't changed on the
+// site, to set it pass the Environment of config.Config.BuildFor to
+// site.EnvVars.PlanSync and ApplySync.
+func (s *DeploysService) CreateFromRepo(root, deployContext, branch string) (*Deploy, *Response, error) {
+	cfg, err := config.LoadToml(root)
+	if os.IsNotExist(err) {
+		cfg = &config.Config{}
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	options := &DeployOptions{Branch: branch, Root: root, Draft: deployContext != "production"}
+	if info, err := ReadGitInfo(root); err == nil {
+		if options.Branch == "" {
+			options.Branch = info.Branch
+		}
+		options.CommitRef = info.CommitRef
+		options.Title = strings.SplitN(info.CommitMessage, "\n", 2)[0]
+	}
+
+	build := cfg.BuildFor(deployContext, options.Branch)
+	if functionsDir := build.FunctionsDir(); functionsDir != "" {
+		return nil, nil, fmt.Errorf("Deploying functions isn't supported, remove the functions directory %s from %s", functionsDir, config.TomlFile)
+	}
+
+	publishDir := filepath.Join(root, build.PublishDir())
+	if err := s.client.validateConfigFiles(publishDir, root); err != nil {
+		return nil, nil, err
+	}
+
+	deploy, resp, err := s.createEmpty(options.Draft)
+	if err != nil {
+		return deploy, resp, err
+	}
+
+	resp, err = deploy.DeployDirWithOptions(publishDir, options)
+	return deploy, resp, err
+}
+
+// validateConfigFiles validates the configuration files of a directory when
+// the client is configured with ValidateConfigFiles. netlify.toml is read
+// from root, or from dir when root is empty.
+func (c *Client) validateConfigFiles(dir, root string) error {
+	if !c.ValidateConfigFiles {
+		return nil
+	}
+	if root == "" {
+		root = dir
+	}
+
+	cfg, err := config.LoadRepo(dir, root)
+	if err != nil {
+		return err
+	}
+	return cfg.Validate()
+}
+
 func (s *DeploysService) create(dirOrZip string, draft bool) (*Deploy, *Response, error) {
+	if !strings.HasSuffix(dirOrZip, ".zip") {
+		if err := s.client.validateConfigFiles(dirOrZip, ""); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	deploy, resp, err := s.createEmpty(draft)
+	if err != nil {
+		return deploy, resp, err
+	}
+
+	resp, err = deploy.Deploy(dirOrZip)
+	return deploy, resp, err
+}
+
+func (s *DeploysService) createEmpty(draft bool) (*Deploy, *Response, error) {
 	if s.site == nil {
 		return nil, nil, errors.New("You can only create a new deploy for an existing site (site.Deploys.Create(dirOrZip)))")
 	}
@@ -130,11 +280,6 @@ func (s *DeploysService) create(dirOrZip string, draft bool) (*Deploy, *Response
 	deploy := &Deploy{client: s.client}
 	resp, err := s.client.Request("POST", s.apiPath(), options, deploy)
 
-	if err != nil {
-		return deploy, resp, err
-	}
-
-	resp, err = deploy.Deploy(dirOrZip)
 	return deploy, resp, err
 }
 
@@ -253,15 +398,42 @@ func (deploy *Deploy) deployDir(dir string) (*Response, error) {
 //
 // This function allows you to supply git information about the deploy
 // when it hasn't been set previously be a Continuous Deployment process.
+//
+// When the client is configured with ValidateConfigFiles, the _redirects,
+// _headers and netlify.toml files in the directory are validated first and
+// nothing is uploaded if they contain errors.
 func (deploy *Deploy) DeployDirWithGitInfo(dir, branch, commitRef string) (*Response, error) {
+	return deploy.DeployDirWithOptions(dir, &DeployOptions{Branch: branch, CommitRef: commitRef})
+}
+
+// DeployDirWithOptions scans the given directory and deploys the files
+// that have changed on Netlify, along with the git info, title, framework
+// and metadata from the DeployOptions.
+//
+// When the client is configured with ValidateConfigFiles, the _redirects and
+// _headers files in the directory and the netlify.toml file in the Root of
+// the DeployOptions, or in the directory itself, are validated first.
+// The options can be nil.
+func (deploy *Deploy) DeployDirWithOptions(dir string, deployOptions *DeployOptions) (*Response, error) {
+	if deployOptions == nil {
+		deployOptions = &DeployOptions{}
+	}
+
 	files := map[string]string{}
 	log := deploy.log().WithFields(logrus.Fields{
 		"dir":        dir,
-		"branch":     branch,
-		"commit_ref": commitRef,
+		"branch":     deployOptions.Branch,
+		"commit_ref": deployOptions.CommitRef,
+		"title":      deployOptions.Title,
+		"framework":  deployOptions.Framework,
 	})
 	defer log.Infof("Finished deploying directory %s", dir)
 
+	if err := deploy.client.validateConfigFiles(dir, deployOptions.Root); err != nil {
+		log.WithError(err).Warn("Invalid configuration files")
+		return nil, err
+	}
+
 	log.Infof("Starting deploy of directory %s", dir)
 	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -298,8 +470,11 @@ func (deploy *Deploy) DeployDirWithGitInfo(dir, branch, commitRef string) (*Resp
 
 	fileOptions := &deployFiles{
 		Files:     &files,
-		Branch:    branch,
-		CommitRef: commitRef,
+		Branch:    deployOptions.Branch,
+		CommitRef: deployOptions.CommitRef,
+		Title:     deployOptions.Title,
+		Framework: deployOptions.Framework,
+		Metadata:  deployOptions.Metadata,
 	}
 
 	if len(files) > MaxFilesForSyncDeploy {
@@ -422,7 +597,7 @@ func (deploy *Deploy) deployZip(zip string) (*Response, error) {
 		"name": info.Name(),
 		"size": info.Size(),
 		"mode": info.Mode(),
-	}).Debugf("Opened file %s of %s bytes", info.Name(), info.Size())
+	}).Debugf("Opened file %s of %d bytes", info.Name(), info.Size())
 
 	options := &RequestOptions{
 		RawBody:       zipFile,
@@ -449,32 +624,77 @@ func (deploy *Deploy) WaitForReady(timeout time.Duration) error {
 		timeout = defaultTimeout
 	}
 
-	timedOut := false
-	time.AfterFunc(timeout*time.Second, func() {
-		timedOut = true
+	ctx, cancel := context.WithTimeout(context.Background(), timeout*time.Second)
+	defer cancel()
+
+	err := poll(ctx, func() (bool, error) {
+		_, err := deploy.Reload()
+		return err != nil || deploy.State == "ready", err
 	})
+	if err == context.DeadlineExceeded {
+		return errors.New("Timeout while waiting for processing")
+	}
+	return err
+}
 
-	done := make(chan error)
+// Log fetches the build log entries for a deploy
+func (deploy *Deploy) Log() ([]LogEntry, *Response, error) {
+	entries := new([]LogEntry)
 
-	go func() {
-		for {
-			time.Sleep(1 * time.Second)
+	resp, err := deploy.client.Request("GET", path.Join(deploy.apiPath(), "log"), nil, entries)
 
-			if timedOut {
-				done <- errors.New("Timeout while waiting for processing")
-				break
-			}
+	return *entries, resp, err
+}
 
-			_, err := deploy.Reload()
-			if err != nil || (deploy.State == "ready") {
-				done <- err
-				break
+// StreamLog writes the build log of a deploy to w, following the log until
+// the deploy is either ready or has failed.
+func (deploy *Deploy) StreamLog(ctx context.Context, w io.Writer) error {
+	written := 0
+
+	return poll(ctx, func() (bool, error) {
+		if _, err := deploy.Reload(); err != nil {
+			return true, err
+		}
+
+		entries, _, err := deploy.Log()
+		if err != nil {
+			return true, err
+		}
+
+		if written > len(entries) {
+			written = len(entries)
+		}
+		for _, entry := range entries[written:] {
+			if _, err := fmt.Fprintln(w, entry.Message); err != nil {
+				return true, err
 			}
 		}
-	}()
+		written = len(entries)
 
-	err := <-done
-	return err
+		return deploy.finished(), nil
+	})
+}
+
+// finished is true when the deploy won't change state anymore
+func (deploy *Deploy) finished() bool {
+	return deploy.State == "ready" || deploy.State == "error"
+}
+
+// poll calls check every deployPollInterval until it reports that it's done
+// or the context is canceled
+func poll(ctx context.Context, check func() (bool, error)) error {
+	for {
+		done, err := check()
+		if done || err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(deployPollInterval):
+		}
+	}
 }
 
 func ignoreFile(rel string) bool {
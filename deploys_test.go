@@ -2,11 +2,17 @@ package netlify
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestDeploysService_List(t *testing.T) {
@@ -236,3 +242,298 @@ func TestDeploysService_CreateDraft_Zip(t *testing.T) {
 		t.Errorf("Expected Deploys.Create to return my-deploy, returned %v", deploy.Id)
 	}
 }
+
+func TestDeploy_Log(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/deploys/my-deploy/log", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"message":"Cloning repository","section":"initializing"},{"message":"Build complete","section":"building"}]`)
+	})
+
+	deploy := &Deploy{Id: "my-deploy", client: client}
+	entries, _, err := deploy.Log()
+	if err != nil {
+		t.Errorf("Deploy.Log returned an error: %v", err)
+	}
+
+	if len(entries) != 2 || entries[1].Message != "Build complete" || entries[1].Section != "building" {
+		t.Errorf("Unexpected log entries: %v", entries)
+	}
+}
+
+func TestDeploy_StreamLog(t *testing.T) {
+	setup()
+	defer teardown()
+
+	deployPollInterval = time.Millisecond
+	defer func() { deployPollInterval = time.Second }()
+
+	polls := 0
+	mux.HandleFunc("/api/v1/deploys/my-deploy", func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		if polls < 3 {
+			fmt.Fprint(w, `{"id":"my-deploy","state":"building"}`)
+		} else {
+			fmt.Fprint(w, `{"id":"my-deploy","state":"ready"}`)
+		}
+	})
+
+	mux.HandleFunc("/api/v1/deploys/my-deploy/log", func(w http.ResponseWriter, r *http.Request) {
+		entries := []string{`{"message":"one"}`, `{"message":"two"}`, `{"message":"three"}`}
+		fmt.Fprintf(w, "[%s]", strings.Join(entries[:polls], ","))
+	})
+
+	deploy := &Deploy{Id: "my-deploy", client: client}
+	buf := new(bytes.Buffer)
+	if err := deploy.StreamLog(context.Background(), buf); err != nil {
+		t.Errorf("Deploy.StreamLog returned an error: %v", err)
+	}
+
+	if expected := "one\ntwo\nthree\n"; buf.String() != expected {
+		t.Errorf("Expected streamed log %q, got %q", expected, buf.String())
+	}
+	if deploy.State != "ready" {
+		t.Errorf("Expected deploy to be ready, was %v", deploy.State)
+	}
+}
+
+func TestDeploy_DeployDirWithGitInfo_Invalid_Config(t *testing.T) {
+	setup()
+	defer teardown()
+
+	dir, err := ioutil.TempDir("", "netlify-deploy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ioutil.WriteFile(filepath.Join(dir, "index.html"), []byte("Hello"), 0644)
+	ioutil.WriteFile(filepath.Join(dir, "_redirects"), []byte("/old /new 999\n"), 0644)
+
+	mux.HandleFunc("/api/v1/deploys/my-deploy", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Nothing should be deployed when the configuration is invalid")
+	})
+
+	client.ValidateConfigFiles = true
+	deploy := &Deploy{Id: "my-deploy", client: client}
+	if _, err := deploy.DeployDirWithGitInfo(dir, "", ""); err == nil {
+		t.Errorf("Expected DeployDirWithGitInfo to fail for an invalid _redirects file")
+	}
+}
+
+func TestDeploysService_CreateWithOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/sites/my-site/deploys", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"id":"my-deploy"}`)
+	})
+
+	mux.HandleFunc("/api/v1/deploys/my-deploy", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		files := &deployFiles{}
+		json.NewDecoder(r.Body).Decode(files)
+
+		if files.Title != "Update the homepage" || files.Framework != "hugo" || files.Metadata["author"] != "jane" {
+			t.Errorf("Unexpected deploy options: %v", files)
+		}
+
+		fmt.Fprint(w, `{"id":"my-deploy","title":"Update the homepage","framework":"hugo","metadata":{"author":"jane"}}`)
+	})
+
+	deploys := &DeploysService{client: client, site: &Site{Id: "my-site"}}
+	deploy, _, err := deploys.CreateWithOptions("test-site/folder", &DeployOptions{
+		Title:     "Update the homepage",
+		Framework: "hugo",
+		Metadata:  map[string]string{"author": "jane"},
+	})
+	if err != nil {
+		t.Fatalf("Deploys.CreateWithOptions returned an error: %v", err)
+	}
+
+	if deploy.Title != "Update the homepage" || deploy.Framework != "hugo" || deploy.Metadata["author"] != "jane" {
+		t.Errorf("Unexpected deploy: %v", deploy)
+	}
+}
+
+func TestDeploysService_CreateWithOptions_Draft(t *testing.T) {
+	setup()
+	defer teardown()
+
+	drafts := []string{}
+	mux.HandleFunc("/api/v1/sites/my-site/deploys", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		drafts = append(drafts, r.URL.Query().Get("draft"))
+		fmt.Fprint(w, `{"id":"my-deploy"}`)
+	})
+
+	mux.HandleFunc("/api/v1/deploys/my-deploy", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		fmt.Fprint(w, `{"id":"my-deploy"}`)
+	})
+
+	deploys := &DeploysService{client: client, site: &Site{Id: "my-site"}}
+	if _, _, err := deploys.CreateWithOptions("test-site/folder", &DeployOptions{Draft: true}); err != nil {
+		t.Errorf("Deploys.CreateWithOptions returned an error: %v", err)
+	}
+	if _, _, err := deploys.CreateWithOptions("test-site/folder", nil); err != nil {
+		t.Errorf("Deploys.CreateWithOptions returned an error for nil options: %v", err)
+	}
+
+	if !reflect.DeepEqual(drafts, []string{"true", ""}) {
+		t.Errorf("Expected only the first deploy to be a draft, got %v", drafts)
+	}
+}
+
+func TestDeploysService_CreateFromRepo(t *testing.T) {
+	setup()
+	defer teardown()
+
+	root, err := ioutil.TempDir("", "netlify-repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	os.MkdirAll(filepath.Join(root, "public"), 0755)
+	os.MkdirAll(filepath.Join(root, "staging"), 0755)
+	ioutil.WriteFile(filepath.Join(root, "netlify.toml"), []byte("[build]\n  publish = \"public\"\n[context.staging]\n  publish = \"staging\"\n"), 0644)
+	ioutil.WriteFile(filepath.Join(root, "public", "index.html"), []byte("Hello"), 0644)
+	ioutil.WriteFile(filepath.Join(root, "staging", "staging.html"), []byte("Hello"), 0644)
+
+	mux.HandleFunc("/api/v1/sites/my-site/deploys", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		if r.URL.Query().Get("draft") != "true" {
+			t.Errorf("Branch deploys should be drafts")
+		}
+		fmt.Fprint(w, `{"id":"my-deploy"}`)
+	})
+
+	mux.HandleFunc("/api/v1/deploys/my-deploy", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		files := &deployFiles{}
+		json.NewDecoder(r.Body).Decode(files)
+
+		if _, ok := (*files.Files)["staging.html"]; !ok || len(*files.Files) != 1 {
+			t.Errorf("Expected the publish directory of the branch to be deployed, got %v", *files.Files)
+		}
+		if files.Branch != "staging" {
+			t.Errorf("Expected the branch to be sent, got %v", files.Branch)
+		}
+
+		fmt.Fprint(w, `{"id":"my-deploy"}`)
+	})
+
+	site := &Site{Id: "my-site"}
+	deploys := &DeploysService{client: client, site: site}
+	if _, _, err := deploys.CreateFromRepo(root, "branch-deploy", "staging"); err != nil {
+		t.Errorf("Deploys.CreateFromRepo returned an error: %v", err)
+	}
+}
+
+func TestDeploysService_CreateFromRepo_Environment(t *testing.T) {
+	setup()
+	defer teardown()
+
+	root, err := ioutil.TempDir("", "netlify-repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	os.MkdirAll(filepath.Join(root, "public"), 0755)
+	ioutil.WriteFile(filepath.Join(root, "netlify.toml"), []byte("[build]\n  publish = \"public\"\n  environment = {API_URL = \"https://api.example.com\"}\n"), 0644)
+	ioutil.WriteFile(filepath.Join(root, "public", "index.html"), []byte("Hello"), 0644)
+
+	mux.HandleFunc("/api/v1/accounts/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("The env vars of the site should not be changed by a deploy")
+	})
+
+	mux.HandleFunc("/api/v1/sites/my-site/deploys", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"my-deploy"}`)
+	})
+
+	mux.HandleFunc("/api/v1/deploys/my-deploy", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"my-deploy"}`)
+	})
+
+	site := &Site{Id: "my-site", AccountSlug: "my-team"}
+	deploys := &DeploysService{client: client, site: site}
+	if _, _, err := deploys.CreateFromRepo(root, "production", "master"); err != nil {
+		t.Errorf("Deploys.CreateFromRepo returned an error: %v", err)
+	}
+}
+
+func TestDeploysService_CreateFromRepo_Functions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	root, err := ioutil.TempDir("", "netlify-repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	ioutil.WriteFile(filepath.Join(root, "netlify.toml"), []byte("[build]\n  publish = \"public\"\n  functions = \"functions\"\n"), 0644)
+
+	mux.HandleFunc("/api/v1/sites/my-site/deploys", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("No deploy should be created when functions can't be deployed")
+	})
+
+	deploys := &DeploysService{client: client, site: &Site{Id: "my-site"}}
+	_, _, err = deploys.CreateFromRepo(root, "production", "master")
+	if err == nil || !strings.Contains(err.Error(), "functions") {
+		t.Errorf("Expected an error about functions, got %v", err)
+	}
+}
+
+func TestDeploysService_CreateFromRepo_Invalid_Config(t *testing.T) {
+	setup()
+	defer teardown()
+
+	root, err := ioutil.TempDir("", "netlify-repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	os.MkdirAll(filepath.Join(root, "public"), 0755)
+	ioutil.WriteFile(filepath.Join(root, "netlify.toml"), []byte("[build]\n  publish = \"public\"\n[[redirects]]\n  from = \"/old\"\n  to = \"/new\"\n  status = 999\n"), 0644)
+	ioutil.WriteFile(filepath.Join(root, "public", "index.html"), []byte("Hello"), 0644)
+
+	mux.HandleFunc("/api/v1/sites/my-site/deploys", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("No deploy should be created when the netlify.toml in the root is invalid")
+	})
+
+	client.ValidateConfigFiles = true
+	deploys := &DeploysService{client: client, site: &Site{Id: "my-site"}}
+	if _, _, err := deploys.CreateFromRepo(root, "production", "master"); err == nil {
+		t.Errorf("Expected Deploys.CreateFromRepo to fail for an invalid netlify.toml")
+	}
+}
+
+func TestDeploysService_CreateWithOptions_Invalid_Config(t *testing.T) {
+	setup()
+	defer teardown()
+
+	dir, err := ioutil.TempDir("", "netlify-deploy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ioutil.WriteFile(filepath.Join(dir, "index.html"), []byte("Hello"), 0644)
+	ioutil.WriteFile(filepath.Join(dir, "_redirects"), []byte("/old /new 999\n"), 0644)
+
+	mux.HandleFunc("/api/v1/sites/my-site/deploys", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("No deploy should be created when the configuration is invalid")
+	})
+
+	client.ValidateConfigFiles = true
+	deploys := &DeploysService{client: client, site: &Site{Id: "my-site"}}
+	if _, _, err := deploys.CreateWithOptions(dir, nil); err == nil {
+		t.Errorf("Expected Deploys.CreateWithOptions to fail for an invalid _redirects file")
+	}
+	if _, _, err := deploys.Create(dir); err == nil {
+		t.Errorf("Expected Deploys.Create to fail for an invalid _redirects file")
+	}
+}
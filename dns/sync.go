@@ -0,0 +1,193 @@
+/*
+Package dns keeps the records of a Netlify DNS zone in sync with a desired
+set of records, for example from a zone file kept in git:
+
+	zone, _, err := client.DNSZones.Get("my-zone-id")
+
+	file, err := os.Open("example.com.zone")
+	records, err := dns.ParseZoneFile(file, zone.Name)
+
+	plan, err := dns.Plan(zone, records)
+	fmt.Print(plan)
+
+	err = dns.Apply(zone, plan)
+*/
+package dns
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/netlify/netlify-go"
+)
+
+// Actions in a Change
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// Change is a single change needed to bring a zone in sync
+type Change struct {
+	Action string
+
+	// Record to create, or the new version of an updated record
+	Record netlify.DNSRecord
+
+	// Current version of an updated or deleted record
+	Existing *netlify.DNSRecord
+}
+
+// SyncPlan lists the changes needed to bring a zone in sync. Creates come
+// before updates, deletes come last.
+type SyncPlan struct {
+	Changes []Change
+}
+
+// Empty is true when the zone is already in sync
+func (p *SyncPlan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// String formats the plan as a human readable preview, one change per line
+func (p *SyncPlan) String() string {
+	buf := new(bytes.Buffer)
+	for _, change := range p.Changes {
+		record := change.Record
+		switch change.Action {
+		case ActionCreate:
+			fmt.Fprintf(buf, "+ %s\n", formatRecord(record))
+		case ActionUpdate:
+			fmt.Fprintf(buf, "~ %s (was %s)\n", formatRecord(record), formatRecord(*change.Existing))
+		case ActionDelete:
+			fmt.Fprintf(buf, "- %s\n", formatRecord(*change.Existing))
+		}
+	}
+	return buf.String()
+}
+
+func (c Change) describe() string {
+	if c.Action == ActionDelete {
+		return formatRecord(*c.Existing)
+	}
+	return formatRecord(c.Record)
+}
+
+func formatRecord(r netlify.DNSRecord) string {
+	s := fmt.Sprintf("%s %d %s", r.Hostname, r.TTL, r.Type)
+	if r.Type == netlify.DNSRecordMX {
+		s += fmt.Sprintf(" %d", r.Priority)
+	}
+	return s + " " + r.Value
+}
+
+// recordKey identifies records that can be updated into each other. Multiple
+// records can exist for the same hostname and type, so the value is part of
+// the key for everything but CNAME and NETLIFY records.
+func recordKey(r netlify.DNSRecord) string {
+	key := strings.ToLower(strings.TrimSuffix(r.Hostname, ".")) + " " + r.Type
+	switch r.Type {
+	case netlify.DNSRecordCNAME, netlify.DNSRecordNETLIFY:
+		return key
+	case netlify.DNSRecordTXT:
+		return key + " " + r.Value
+	default:
+		return key + " " + strings.ToLower(strings.TrimSuffix(r.Value, "."))
+	}
+}
+
+// ComputePlan compares the current records of a zone with the desired records.
+// Records managed by netlify are never deleted.
+func ComputePlan(current, desired []netlify.DNSRecord) (*SyncPlan, error) {
+	existing := map[string]*netlify.DNSRecord{}
+	for i := range current {
+		existing[recordKey(current[i])] = &current[i]
+	}
+
+	plan := &SyncPlan{}
+	var updates []Change
+	seen := map[string]bool{}
+	for _, record := range desired {
+		if err := record.Validate(); err != nil {
+			return nil, err
+		}
+
+		key := recordKey(record)
+		if seen[key] {
+			return nil, fmt.Errorf("Duplicate DNS record %s", formatRecord(record))
+		}
+		seen[key] = true
+
+		old, ok := existing[key]
+		switch {
+		case !ok:
+			plan.Changes = append(plan.Changes, Change{Action: ActionCreate, Record: record})
+		case changed(*old, record):
+			updates = append(updates, Change{Action: ActionUpdate, Record: record, Existing: old})
+		}
+	}
+	plan.Changes = append(plan.Changes, updates...)
+
+	for i := range current {
+		if !seen[recordKey(current[i])] && !current[i].Managed {
+			plan.Changes = append(plan.Changes, Change{Action: ActionDelete, Existing: &current[i]})
+		}
+	}
+
+	return plan, nil
+}
+
+func changed(old, record netlify.DNSRecord) bool {
+	return old.Value != record.Value ||
+		old.Priority != record.Priority ||
+		(record.TTL != 0 && old.TTL != record.TTL)
+}
+
+// Plan computes the changes needed to make the records of zone match desired
+func Plan(zone *netlify.DNSZone, desired []netlify.DNSRecord) (*SyncPlan, error) {
+	current, _, err := zone.Records.List()
+	if err != nil {
+		return nil, err
+	}
+
+	return ComputePlan(current, desired)
+}
+
+// Apply executes a plan against a zone, stopping at the first error.
+// Updated records are replaced with DNSRecordsService.Update, which creates
+// the new record before the old one is deleted, so a failed change doesn't
+// leave a hostname without a record. CNAME and NETLIFY records can't share
+// their hostname, so those are deleted before their replacement is created.
+// Records are deleted through the zone, so plans don't need to come from Plan.
+func Apply(zone *netlify.DNSZone, plan *SyncPlan) error {
+	for _, change := range plan.Changes {
+		var err error
+		switch change.Action {
+		case ActionCreate:
+			_, _, err = zone.Records.Create(&change.Record)
+		case ActionUpdate:
+			record := change.Record
+			record.Id = change.Existing.Id
+			_, _, err = zone.Records.Update(&record)
+		case ActionDelete:
+			_, err = zone.Records.Destroy(change.Existing.Id)
+		}
+		if err != nil {
+			return fmt.Errorf("Error applying %s of %s: %v", change.Action, change.describe(), err)
+		}
+	}
+	return nil
+}
+
+// Sync plans and applies the changes needed to make the records of zone
+// match desired, and returns the applied plan
+func Sync(zone *netlify.DNSZone, desired []netlify.DNSRecord) (*SyncPlan, error) {
+	plan, err := Plan(zone, desired)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, Apply(zone, plan)
+}
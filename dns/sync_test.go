@@ -0,0 +1,137 @@
+package dns
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/netlify/netlify-go"
+)
+
+func TestComputePlan(t *testing.T) {
+	current := []netlify.DNSRecord{
+		{Id: "1", Hostname: "example.com", Type: "A", Value: "104.198.14.52", TTL: 3600},
+		{Id: "2", Hostname: "www.example.com", Type: "CNAME", Value: "old-site.netlify.com", TTL: 3600},
+		{Id: "3", Hostname: "ftp.example.com", Type: "A", Value: "10.0.0.1", TTL: 3600},
+		{Id: "4", Hostname: "example.com", Type: "NETLIFY", Value: "my-site.netlify.com", Managed: true},
+	}
+	desired := []netlify.DNSRecord{
+		{Hostname: "example.com", Type: "A", Value: "104.198.14.52", TTL: 3600},
+		{Hostname: "www.example.com", Type: "CNAME", Value: "my-site.netlify.com", TTL: 3600},
+		{Hostname: "example.com", Type: "TXT", Value: "v=spf1 -all"},
+	}
+
+	plan, err := ComputePlan(current, desired)
+	if err != nil {
+		t.Fatalf("ComputePlan returned an error: %v", err)
+	}
+
+	expected := "" +
+		"+ example.com 0 TXT v=spf1 -all\n" +
+		"~ www.example.com 3600 CNAME my-site.netlify.com (was www.example.com 3600 CNAME old-site.netlify.com)\n" +
+		"- ftp.example.com 3600 A 10.0.0.1\n"
+	if plan.String() != expected {
+		t.Errorf("Expected plan:\n%v\ngot:\n%v", expected, plan)
+	}
+}
+
+func TestComputePlan_Duplicate(t *testing.T) {
+	record := netlify.DNSRecord{Hostname: "example.com", Type: "A", Value: "104.198.14.52"}
+
+	if _, err := ComputePlan(nil, []netlify.DNSRecord{record, record}); err == nil {
+		t.Errorf("Expected ComputePlan to reject duplicate records")
+	}
+}
+
+// fakeZone serves the records of my-zone from memory and, like the API,
+// rejects records that would share a hostname with a CNAME or NETLIFY record
+type fakeZone struct {
+	records  map[string]netlify.DNSRecord
+	requests []string
+	nextId   int
+}
+
+func (z *fakeZone) serve() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/dns_zones/my-zone", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"my-zone","name":"example.com"}`)
+	})
+	mux.HandleFunc("/api/v1/dns_zones/my-zone/dns_records", func(w http.ResponseWriter, r *http.Request) {
+		record := netlify.DNSRecord{}
+		json.NewDecoder(r.Body).Decode(&record)
+		z.requests = append(z.requests, "POST "+record.Type)
+
+		for _, existing := range z.records {
+			exclusive := existing.Type == "CNAME" || existing.Type == "NETLIFY" || record.Type == "CNAME" || record.Type == "NETLIFY"
+			if existing.Hostname == record.Hostname && exclusive {
+				http.Error(w, "Conflict", http.StatusConflict)
+				return
+			}
+		}
+
+		z.nextId++
+		record.Id = fmt.Sprintf("new-%d", z.nextId)
+		z.records[record.Id] = record
+		json.NewEncoder(w).Encode(record)
+	})
+	mux.HandleFunc("/api/v1/dns_zones/my-zone/dns_records/", func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/api/v1/dns_zones/my-zone/dns_records/")
+		z.requests = append(z.requests, r.Method+" "+id)
+		delete(z.records, id)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestApply(t *testing.T) {
+	// Existing records that didn't come from the API have no client
+	current := []netlify.DNSRecord{
+		{Id: "1", Hostname: "www.example.com", Type: "CNAME", Value: "old-site.netlify.com"},
+		{Id: "2", Hostname: "ftp.example.com", Type: "A", Value: "10.0.0.1"},
+		{Id: "3", Hostname: "example.com", Type: "MX", Value: "mx.example.com", Priority: 20},
+	}
+	desired := []netlify.DNSRecord{
+		{Hostname: "www.example.com", Type: "CNAME", Value: "my-site.netlify.com"},
+		{Hostname: "example.com", Type: "MX", Value: "mx.example.com", Priority: 10},
+	}
+
+	fake := &fakeZone{records: map[string]netlify.DNSRecord{}}
+	for _, record := range current {
+		fake.records[record.Id] = record
+	}
+	server := fake.serve()
+	defer server.Close()
+
+	client := netlify.NewClient(&netlify.Config{HttpClient: http.DefaultClient, BaseUrl: server.URL})
+	zone, _, err := client.DNSZones.Get("my-zone")
+	if err != nil {
+		t.Fatalf("DNSZones.Get returned an error: %v", err)
+	}
+
+	plan, err := ComputePlan(current, desired)
+	if err != nil {
+		t.Fatalf("ComputePlan returned an error: %v", err)
+	}
+
+	if err := Apply(zone, plan); err != nil {
+		t.Fatalf("Apply returned an error: %v", err)
+	}
+
+	// The CNAME is replaced delete first, the MX record create first
+	expected := "DELETE 1, POST CNAME, POST MX, DELETE 3, DELETE 2"
+	if strings.Join(fake.requests, ", ") != expected {
+		t.Errorf("Expected requests %v, got %v", expected, strings.Join(fake.requests, ", "))
+	}
+
+	if len(fake.records) != 2 {
+		t.Errorf("Expected the zone to only have the desired records, got %v", fake.records)
+	}
+	for _, record := range fake.records {
+		if record.Type == "CNAME" && record.Value != "my-site.netlify.com" {
+			t.Errorf("Expected the CNAME to be updated, got %v", record)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package dns
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/netlify/netlify-go"
+)
+
+// ParseZoneFile parses an RFC 1035 master file into DNS records. Relative
+// names are qualified with origin, which can be changed by $ORIGIN
+// directives in the file.
+//
+// SOA and NS records for the zone are managed by Netlify DNS and are
+// skipped. Other record types that Netlify DNS doesn't support are an error.
+func ParseZoneFile(r io.Reader, origin string) ([]netlify.DNSRecord, error) {
+	p := &zoneParser{origin: strings.TrimSuffix(origin, ".")}
+
+	scanner := bufio.NewScanner(r)
+	var entry []string
+	var blankOwner bool
+	depth, start := 0, 0
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		tokens, err := tokenize(line)
+		if err != nil {
+			return nil, fmt.Errorf("Line %d: %v", n, err)
+		}
+
+		if depth == 0 {
+			if len(tokens) == 0 {
+				continue
+			}
+			start = n
+			blankOwner = line[0] == ' ' || line[0] == '\t'
+		}
+
+		for _, token := range tokens {
+			switch token {
+			case "(":
+				depth++
+			case ")":
+				depth--
+			default:
+				entry = append(entry, token)
+			}
+		}
+		if depth < 0 {
+			return nil, fmt.Errorf("Line %d: unbalanced parentheses", n)
+		}
+
+		if depth == 0 {
+			if err := p.parseEntry(entry, blankOwner); err != nil {
+				return nil, fmt.Errorf("Line %d: %v", start, err)
+			}
+			entry = nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("Line %d: unbalanced parentheses", start)
+	}
+
+	return p.records, nil
+}
+
+type zoneParser struct {
+	origin  string
+	ttl     int64
+	owner   string
+	records []netlify.DNSRecord
+}
+
+func (p *zoneParser) parseEntry(tokens []string, blankOwner bool) error {
+	switch strings.ToUpper(tokens[0]) {
+	case "$ORIGIN":
+		if len(tokens) != 2 {
+			return fmt.Errorf("$ORIGIN takes a single domain name")
+		}
+		p.origin = p.qualify(tokens[1])
+		return nil
+	case "$TTL":
+		if len(tokens) != 2 {
+			return fmt.Errorf("$TTL takes a single value")
+		}
+		ttl, err := strconv.ParseInt(tokens[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("Invalid $TTL %s", tokens[1])
+		}
+		p.ttl = ttl
+		return nil
+	case "$INCLUDE", "$GENERATE":
+		return fmt.Errorf("%s directives are not supported", tokens[0])
+	}
+
+	if !blankOwner {
+		p.owner = p.qualify(tokens[0])
+		tokens = tokens[1:]
+	}
+	if p.owner == "" {
+		return fmt.Errorf("Record without an owner name")
+	}
+
+	record := netlify.DNSRecord{Hostname: p.owner, TTL: p.ttl}
+	for len(tokens) > 0 && record.Type == "" {
+		token := strings.ToUpper(tokens[0])
+		tokens = tokens[1:]
+		if ttl, err := strconv.ParseInt(token, 10, 64); err == nil {
+			record.TTL = ttl
+		} else if token != "IN" {
+			record.Type = token
+		}
+	}
+
+	switch record.Type {
+	case "":
+		return fmt.Errorf("Record for %s is missing a type", p.owner)
+	case "SOA", "NS":
+		return nil
+	case netlify.DNSRecordA, netlify.DNSRecordAAAA:
+		if len(tokens) != 1 {
+			return fmt.Errorf("%s record for %s takes a single address", record.Type, p.owner)
+		}
+		record.Value = tokens[0]
+	case netlify.DNSRecordCNAME:
+		if len(tokens) != 1 {
+			return fmt.Errorf("CNAME record for %s takes a single target", p.owner)
+		}
+		record.Value = p.qualify(tokens[0])
+	case netlify.DNSRecordMX:
+		if len(tokens) != 2 {
+			return fmt.Errorf("MX record for %s takes a priority and a mail server", p.owner)
+		}
+		priority, err := strconv.ParseInt(tokens[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("Invalid MX priority %s for %s", tokens[0], p.owner)
+		}
+		record.Priority = priority
+		record.Value = p.qualify(tokens[1])
+	case netlify.DNSRecordTXT:
+		if len(tokens) == 0 {
+			return fmt.Errorf("TXT record for %s is missing its text", p.owner)
+		}
+		for _, token := range tokens {
+			record.Value += strings.TrimPrefix(token, `"`)
+		}
+	default:
+		return fmt.Errorf("Unsupported record type %s for %s", record.Type, p.owner)
+	}
+
+	if err := record.Validate(); err != nil {
+		return err
+	}
+
+	p.records = append(p.records, record)
+	return nil
+}
+
+// qualify turns a name relative to the origin into a hostname without the
+// trailing dot
+func (p *zoneParser) qualify(name string) string {
+	switch {
+	case name == "@":
+		return p.origin
+	case strings.HasSuffix(name, "."):
+		return strings.TrimSuffix(name, ".")
+	case p.origin == "":
+		return name
+	default:
+		return name + "." + p.origin
+	}
+}
+
+// tokenize splits a line into whitespace separated tokens, stripping
+// comments. Parentheses are returned as separate tokens and quoted strings
+// are returned as a single token with a leading quote and without escapes.
+func tokenize(line string) ([]string, error) {
+	var tokens []string
+	var current []rune
+	inToken, quoted, escaped := false, false, false
+
+	flush := func() {
+		if inToken {
+			tokens = append(tokens, string(current))
+		}
+		current, inToken = nil, false
+	}
+
+	for _, c := range line {
+		switch {
+		case escaped:
+			current = append(current, c)
+			escaped = false
+		case c == '\\':
+			escaped, inToken = true, true
+		case quoted && c == '"':
+			quoted = false
+			flush()
+		case quoted:
+			current = append(current, c)
+		case c == '"':
+			flush()
+			quoted, inToken = true, true
+			current = []rune{'"'}
+		case c == ';':
+			flush()
+			return tokens, nil
+		case c == '(' || c == ')':
+			flush()
+			tokens = append(tokens, string(c))
+		case c == ' ' || c == '\t':
+			flush()
+		default:
+			current = append(current, c)
+			inToken = true
+		}
+	}
+
+	if quoted {
+		return nil, fmt.Errorf("Unterminated quoted string")
+	}
+	flush()
+	return tokens, nil
+}
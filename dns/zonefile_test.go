@@ -0,0 +1,59 @@
+package dns
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/netlify/netlify-go"
+)
+
+const testZoneFile = `
+$ORIGIN example.com.
+$TTL 3600
+@       IN  SOA  dns1.p01.nsone.net. hostmaster.nsone.net. (
+                 2017070501 ; serial
+                 43200 7200 1209600 3600 )
+        IN  NS   dns1.p01.nsone.net.
+@       IN  A    104.198.14.52
+www  300 IN CNAME my-site.netlify.com.
+blog        CNAME blog.example.net.
+@           MX   10 mx1
+            MX   20 mx2.example.net.
+@           TXT  "v=spf1 include:_spf.example.net" " -all" ; SPF
+`
+
+func TestParseZoneFile(t *testing.T) {
+	records, err := ParseZoneFile(strings.NewReader(testZoneFile), "")
+	if err != nil {
+		t.Fatalf("ParseZoneFile returned an error: %v", err)
+	}
+
+	expected := []netlify.DNSRecord{
+		{Hostname: "example.com", Type: "A", Value: "104.198.14.52", TTL: 3600},
+		{Hostname: "www.example.com", Type: "CNAME", Value: "my-site.netlify.com", TTL: 300},
+		{Hostname: "blog.example.com", Type: "CNAME", Value: "blog.example.net", TTL: 3600},
+		{Hostname: "example.com", Type: "MX", Value: "mx1.example.com", Priority: 10, TTL: 3600},
+		{Hostname: "example.com", Type: "MX", Value: "mx2.example.net", Priority: 20, TTL: 3600},
+		{Hostname: "example.com", Type: "TXT", Value: "v=spf1 include:_spf.example.net -all", TTL: 3600},
+	}
+	if !reflect.DeepEqual(records, expected) {
+		t.Errorf("Expected records:\n%v\ngot:\n%v", expected, records)
+	}
+}
+
+func TestParseZoneFile_Errors(t *testing.T) {
+	cases := []string{
+		"www IN SRV 10 5 5060 sip.example.com.",
+		"www IN A 2001:db8::1",
+		"@ IN SOA ns. host. ( 1 2 3 4 5",
+		"$INCLUDE other.zone",
+		`@ TXT "unterminated`,
+	}
+
+	for _, zoneFile := range cases {
+		if _, err := ParseZoneFile(strings.NewReader(zoneFile), "example.com"); err == nil {
+			t.Errorf("Expected ParseZoneFile to fail for %q", zoneFile)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package netlify
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"path"
+	"strings"
+)
+
+// Types of DNS records supported by Netlify DNS
+const (
+	DNSRecordA       = "A"
+	DNSRecordAAAA    = "AAAA"
+	DNSRecordCNAME   = "CNAME"
+	DNSRecordMX      = "MX"
+	DNSRecordTXT     = "TXT"
+	DNSRecordNETLIFY = "NETLIFY"
+)
+
+// DNSRecord is a single record in a DNS zone
+type DNSRecord struct {
+	Id        string `json:"id,omitempty"`
+	DNSZoneId string `json:"dns_zone_id,omitempty"`
+	SiteId    string `json:"site_id,omitempty"`
+
+	// Fully qualified hostname of the record, ie. www.example.com
+	Hostname string `json:"hostname"`
+
+	// One of the DNSRecord type constants
+	Type string `json:"type"`
+
+	// IP for A/AAAA records, target hostname for CNAME/MX records, text
+	// for TXT records and the netlify subdomain for NETLIFY records
+	Value string `json:"value"`
+
+	TTL int64 `json:"ttl,omitempty"`
+
+	// Priority of MX records. It's always sent, since 0 is a valid priority.
+	Priority int64 `json:"priority"`
+
+	// Managed records are created by netlify and can't be changed
+	Managed bool `json:"managed,omitempty"`
+
+	client *Client
+}
+
+// DNSRecordsService is used to access all DNSRecord related API methods
+type DNSRecordsService struct {
+	zone   *DNSZone
+	client *Client
+}
+
+// Validate checks that the record is well formed before sending it to the API
+func (r *DNSRecord) Validate() error {
+	if r.Hostname == "" {
+		return errors.New("DNS record is missing a hostname")
+	}
+	if r.Value == "" {
+		return fmt.Errorf("%s record for %s is missing a value", r.Type, r.Hostname)
+	}
+	if r.TTL < 0 {
+		return fmt.Errorf("%s record for %s has a negative TTL", r.Type, r.Hostname)
+	}
+
+	switch r.Type {
+	case DNSRecordA:
+		if ip := net.ParseIP(r.Value); ip == nil || ip.To4() == nil {
+			return fmt.Errorf("A record for %s must point to an IPv4 address, got %s", r.Hostname, r.Value)
+		}
+	case DNSRecordAAAA:
+		if ip := net.ParseIP(r.Value); ip == nil || ip.To4() != nil {
+			return fmt.Errorf("AAAA record for %s must point to an IPv6 address, got %s", r.Hostname, r.Value)
+		}
+	case DNSRecordCNAME, DNSRecordMX, DNSRecordNETLIFY:
+		if !validHostname(r.Value) {
+			return fmt.Errorf("%s record for %s must point to a hostname, got %s", r.Type, r.Hostname, r.Value)
+		}
+	case DNSRecordTXT:
+	default:
+		return fmt.Errorf("Unsupported DNS record type %s", r.Type)
+	}
+
+	if r.Priority != 0 && r.Type != DNSRecordMX {
+		return fmt.Errorf("Only MX records can have a priority, got a %s record for %s", r.Type, r.Hostname)
+	}
+
+	return nil
+}
+
+func validHostname(hostname string) bool {
+	hostname = strings.TrimSuffix(hostname, ".")
+	if hostname == "" || len(hostname) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(hostname, ".") {
+		if label == "" || len(label) > 63 || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return false
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (s *DNSRecordsService) apiPath() string {
+	return path.Join(s.zone.apiPath(), "dns_records")
+}
+
+// Create a new record in the zone. The record is validated first and must
+// have a hostname within the zone.
+func (s *DNSRecordsService) Create(record *DNSRecord) (*DNSRecord, *Response, error) {
+	if err := s.validate(record); err != nil {
+		return nil, nil, err
+	}
+
+	created := &DNSRecord{DNSZoneId: s.zone.Id, client: s.client}
+
+	reqOptions := &RequestOptions{JsonBody: record}
+
+	resp, err := s.client.Request("POST", s.apiPath(), reqOptions, created)
+
+	return created, resp, err
+}
+
+// List all records in the zone
+func (s *DNSRecordsService) List() ([]DNSRecord, *Response, error) {
+	records := new([]DNSRecord)
+
+	resp, err := s.client.Request("GET", s.apiPath(), nil, records)
+
+	for i := range *records {
+		(*records)[i].client = s.client
+		if (*records)[i].DNSZoneId == "" {
+			(*records)[i].DNSZoneId = s.zone.Id
+		}
+	}
+
+	return *records, resp, err
+}
+
+// Get a specific record.
+func (s *DNSRecordsService) Get(id string) (*DNSRecord, *Response, error) {
+	record := &DNSRecord{Id: id, DNSZoneId: s.zone.Id, client: s.client}
+	resp, err := record.Reload()
+
+	return record, resp, err
+}
+
+// Update replaces a record. Netlify DNS records can't be changed in place,
+// so a new record is created first and the old record is only deleted once
+// the replacement exists. If deleting the old record fails, the replacement
+// is returned along with the error and both records are left in the zone.
+//
+// CNAME and NETLIFY records can't share their hostname with another record,
+// so for those the old record is deleted first. If creating the replacement
+// fails, the hostname is left without a record.
+func (s *DNSRecordsService) Update(record *DNSRecord) (*DNSRecord, *Response, error) {
+	if record.Id == "" {
+		return nil, nil, errors.New("Cannot update DNS record without an ID")
+	}
+	if err := s.validate(record); err != nil {
+		return nil, nil, err
+	}
+
+	replacement := *record
+	replacement.Id = ""
+
+	if exclusiveRecord(record.Type) {
+		if resp, err := s.Destroy(record.Id); err != nil {
+			return nil, resp, err
+		}
+		return s.Create(&replacement)
+	}
+
+	created, resp, err := s.Create(&replacement)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	resp, err = s.Destroy(record.Id)
+
+	return created, resp, err
+}
+
+// exclusiveRecord is true for record types that must be the only record for
+// their hostname
+func exclusiveRecord(recordType string) bool {
+	return recordType == DNSRecordCNAME || recordType == DNSRecordNETLIFY
+}
+
+// validate checks the record and that its hostname is within the zone
+func (s *DNSRecordsService) validate(record *DNSRecord) error {
+	if err := record.Validate(); err != nil {
+		return err
+	}
+	if !s.inZone(record.Hostname) {
+		return fmt.Errorf("Hostname %s is not part of the DNS zone %s", record.Hostname, s.zone.Name)
+	}
+	return nil
+}
+
+// Destroy deletes the record with the given id from the zone
+func (s *DNSRecordsService) Destroy(id string) (*Response, error) {
+	if id == "" {
+		return nil, errors.New("Cannot delete DNS record without an ID")
+	}
+	record := &DNSRecord{Id: id, DNSZoneId: s.zone.Id, client: s.client}
+	return record.Destroy()
+}
+
+func (s *DNSRecordsService) inZone(hostname string) bool {
+	hostname = strings.ToLower(strings.TrimSuffix(hostname, "."))
+	zone := strings.ToLower(strings.TrimSuffix(s.zone.Name, "."))
+	return zone == "" || hostname == zone || strings.HasSuffix(hostname, "."+zone)
+}
+
+func (record *DNSRecord) apiPath() string {
+	return path.Join("/dns_zones", record.DNSZoneId, "dns_records", record.Id)
+}
+
+// Reload a record from the API
+func (record *DNSRecord) Reload() (*Response, error) {
+	if record.Id == "" {
+		return nil, errors.New("Cannot fetch DNS record without an ID")
+	}
+	return record.client.Request("GET", record.apiPath(), nil, record)
+}
+
+// Destroy deletes a record permanently
+func (record *DNSRecord) Destroy() (*Response, error) {
+	resp, err := record.client.Request("DELETE", record.apiPath(), nil, nil)
+	if resp != nil && resp.Body != nil {
+		resp.Body.Close()
+	}
+	return resp, err
+}
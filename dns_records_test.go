@@ -0,0 +1,146 @@
+package netlify
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDNSRecord_Validate(t *testing.T) {
+	cases := []struct {
+		record DNSRecord
+		valid  bool
+	}{
+		{DNSRecord{Hostname: "example.com", Type: DNSRecordA, Value: "104.198.14.52"}, true},
+		{DNSRecord{Hostname: "example.com", Type: DNSRecordA, Value: "2001:db8::1"}, false},
+		{DNSRecord{Hostname: "example.com", Type: DNSRecordAAAA, Value: "2001:db8::1"}, true},
+		{DNSRecord{Hostname: "www.example.com", Type: DNSRecordCNAME, Value: "my-site.netlify.com"}, true},
+		{DNSRecord{Hostname: "www.example.com", Type: DNSRecordCNAME, Value: "not a hostname"}, false},
+		{DNSRecord{Hostname: "example.com", Type: DNSRecordMX, Value: "mx.example.com", Priority: 10}, true},
+		{DNSRecord{Hostname: "example.com", Type: DNSRecordTXT, Value: "v=spf1 -all", Priority: 10}, false},
+		{DNSRecord{Hostname: "example.com", Type: DNSRecordTXT, Value: ""}, false},
+		{DNSRecord{Hostname: "example.com", Type: "SRV", Value: "srv.example.com"}, false},
+		{DNSRecord{Type: DNSRecordA, Value: "104.198.14.52"}, false},
+	}
+
+	for _, c := range cases {
+		err := c.record.Validate()
+		if c.valid && err != nil {
+			t.Errorf("Expected %v to be valid, got %v", c.record, err)
+		}
+		if !c.valid && err == nil {
+			t.Errorf("Expected %v to be invalid", c.record)
+		}
+	}
+}
+
+func TestDNSRecordsService_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/dns_zones/my-zone/dns_records", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"id":"my-record","hostname":"www.example.com","type":"CNAME","value":"my-site.netlify.com"}`)
+	})
+
+	zone := client.DNSZones.newZone(&DNSZone{Id: "my-zone", Name: "example.com"})
+
+	record, _, err := zone.Records.Create(&DNSRecord{Hostname: "www.example.com", Type: DNSRecordCNAME, Value: "my-site.netlify.com"})
+	if err != nil {
+		t.Errorf("DNSRecords.Create returned an error: %v", err)
+	}
+	if record.Id != "my-record" || record.apiPath() != "/dns_zones/my-zone/dns_records/my-record" {
+		t.Errorf("Unexpected record returned from DNSRecords.Create: %v", record)
+	}
+
+	_, _, err = zone.Records.Create(&DNSRecord{Hostname: "www.other.com", Type: DNSRecordCNAME, Value: "my-site.netlify.com"})
+	if err == nil {
+		t.Errorf("Expected DNSRecords.Create to reject a hostname outside the zone")
+	}
+}
+
+func TestDNSRecordsService_Update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	created := false
+	mux.HandleFunc("/api/v1/dns_zones/my-zone/dns_records", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		buf := new(bytes.Buffer)
+		buf.ReadFrom(r.Body)
+		if !strings.Contains(buf.String(), `"priority":0`) {
+			t.Errorf("Expected a priority of 0 to be sent, got %v", buf.String())
+		}
+
+		created = true
+		fmt.Fprint(w, `{"id":"new-record","hostname":"example.com","type":"MX","value":"mx.example.com","priority":0}`)
+	})
+
+	mux.HandleFunc("/api/v1/dns_zones/my-zone/dns_records/old-record", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		if !created {
+			t.Errorf("Expected the old record to be deleted after the replacement was created")
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	zone := client.DNSZones.newZone(&DNSZone{Id: "my-zone", Name: "example.com"})
+
+	record, _, err := zone.Records.Update(&DNSRecord{Id: "old-record", Hostname: "example.com", Type: DNSRecordMX, Value: "mx.example.com"})
+	if err != nil {
+		t.Errorf("DNSRecords.Update returned an error: %v", err)
+	}
+	if record.Id != "new-record" {
+		t.Errorf("Expected DNSRecords.Update to return the replacement, got %v", record)
+	}
+}
+
+func TestDNSRecordsService_Update_Invalid(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/dns_zones/my-zone/dns_records/old-record", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("The old record should not be deleted when the replacement is invalid")
+	})
+
+	zone := client.DNSZones.newZone(&DNSZone{Id: "my-zone", Name: "example.com"})
+
+	_, _, err := zone.Records.Update(&DNSRecord{Id: "old-record", Hostname: "www.other.com", Type: DNSRecordCNAME, Value: "my-site.netlify.com"})
+	if err == nil {
+		t.Errorf("Expected DNSRecords.Update to reject a hostname outside the zone")
+	}
+}
+
+func TestDNSRecordsService_Update_CNAME(t *testing.T) {
+	setup()
+	defer teardown()
+
+	deleted := false
+	mux.HandleFunc("/api/v1/dns_zones/my-zone/dns_records/old-record", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		deleted = true
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("/api/v1/dns_zones/my-zone/dns_records", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		if !deleted {
+			http.Error(w, "Conflict", http.StatusConflict)
+			return
+		}
+		fmt.Fprint(w, `{"id":"new-record","hostname":"www.example.com","type":"CNAME","value":"my-site.netlify.com"}`)
+	})
+
+	zone := client.DNSZones.newZone(&DNSZone{Id: "my-zone", Name: "example.com"})
+
+	record, _, err := zone.Records.Update(&DNSRecord{Id: "old-record", Hostname: "www.example.com", Type: DNSRecordCNAME, Value: "my-site.netlify.com"})
+	if err != nil {
+		t.Fatalf("DNSRecords.Update returned an error: %v", err)
+	}
+	if record.Id != "new-record" {
+		t.Errorf("Expected DNSRecords.Update to return the replacement, got %v", record)
+	}
+}
@@ -0,0 +1,107 @@
+package netlify
+
+import (
+	"errors"
+	"path"
+)
+
+// DNSZone is a domain managed by Netlify DNS
+type DNSZone struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	AccountSlug string `json:"account_slug"`
+	SiteId      string `json:"site_id"`
+	UserId      string `json:"user_id"`
+
+	// Name servers the domain must be delegated to
+	DNSServers []string `json:"dns_servers"`
+
+	CreatedAt Timestamp `json:"created_at"`
+	UpdatedAt Timestamp `json:"updated_at"`
+
+	// Access the records of this zone
+	Records *DNSRecordsService `json:"-"`
+
+	client *Client
+}
+
+// DNSZonesService is used to access all DNSZone related API methods
+type DNSZonesService struct {
+	client *Client
+}
+
+// Attributes for DNSZones.Create
+type DNSZoneAttributes struct {
+	Name        string `json:"name"`
+	AccountSlug string `json:"account_slug,omitempty"`
+	SiteId      string `json:"site_id,omitempty"`
+}
+
+func (s *DNSZonesService) newZone(zone *DNSZone) *DNSZone {
+	zone.client = s.client
+	zone.Records = &DNSRecordsService{client: s.client, zone: zone}
+	return zone
+}
+
+// Create a new DNS zone. Set the SiteId to use the zone for the custom
+// domain of a site.
+func (s *DNSZonesService) Create(attributes *DNSZoneAttributes) (*DNSZone, *Response, error) {
+	zone := s.newZone(&DNSZone{})
+
+	reqOptions := &RequestOptions{JsonBody: attributes}
+
+	resp, err := s.client.Request("POST", "/dns_zones", reqOptions, zone)
+
+	return zone, resp, err
+}
+
+// List all DNS zones you have access to
+func (s *DNSZonesService) List() ([]DNSZone, *Response, error) {
+	return s.list("/dns_zones")
+}
+
+// ListForSite lists the DNS zones used by the custom domain and aliases of a site
+func (s *DNSZonesService) ListForSite(siteId string) ([]DNSZone, *Response, error) {
+	return s.list(path.Join("/sites", siteId, "dns"))
+}
+
+func (s *DNSZonesService) list(apiPath string) ([]DNSZone, *Response, error) {
+	zones := new([]DNSZone)
+
+	resp, err := s.client.Request("GET", apiPath, nil, zones)
+
+	for i := range *zones {
+		s.newZone(&(*zones)[i])
+	}
+
+	return *zones, resp, err
+}
+
+// Get a specific DNS zone.
+func (s *DNSZonesService) Get(id string) (*DNSZone, *Response, error) {
+	zone := s.newZone(&DNSZone{Id: id})
+	resp, err := zone.Reload()
+
+	return zone, resp, err
+}
+
+func (zone *DNSZone) apiPath() string {
+	return path.Join("/dns_zones", zone.Id)
+}
+
+// Reload a DNS zone from the API
+func (zone *DNSZone) Reload() (*Response, error) {
+	if zone.Id == "" {
+		return nil, errors.New("Cannot fetch DNS zone without an ID")
+	}
+	return zone.client.Request("GET", zone.apiPath(), nil, zone)
+}
+
+// Destroy deletes a DNS zone and all its records permanently
+func (zone *DNSZone) Destroy() (*Response, error) {
+	resp, err := zone.client.Request("DELETE", zone.apiPath(), nil, nil)
+	if resp != nil && resp.Body != nil {
+		resp.Body.Close()
+	}
+	return resp, err
+}
@@ -31,32 +31,63 @@ To work with the netlify API, start by instantiating a client:
     // Update the site
     resp, err := site.Update()
 
+    // Deploy the master branch from a repository, using the publish
+    // directory from its netlify.toml
+    deploy, resp, err := site.Deploys.CreateFromRepo("/path/to/repo", "production", "master")
+
+    // Deploy a directory with a deploy message shown in the deploy list
+    deploy, resp, err := site.Deploys.CreateWithOptions("/path/to/site-dir", &netlify.DeployOptions{
+      Title: "Update the homepage",
+      Metadata: map[string]string{"deployed_by": "release-bot"},
+    })
+
     // Deploy a new version of the site from a zip file
     deploy, resp, err := site.Deploys.Create("/path/to/file.zip")
     deploy.WaitForReady(0)
 
-    // Configure Continuous Deployment for a site
+    // Configure Continuous Deployment for a site with a repo on
+    // GitHub, GitLab or Bitbucket. This creates a deploy key and adds
+    // it and the deploy hook of the site to the repository
+    resp, err = site.ConnectRepo(ctx, &netlify.GitHubProvider{Token: githubToken}, &netlify.RepoOptions{
+      Repo: "netlify/netlify-home",
+      Dir: "_site",
+      Cmd: "gulp build",
+      Branch: "master",
+    })
 
-    // First get a deploy key
+    // Or configure the repo by hand. First get a deploy key
     deployKey, resp, err := site.DeployKeys.Create()
     // Then make sure the public key (deployKey.PublicKey)
     // has access to the repository
 
     // Configure the repo
-    resp, err = site.ContinuousDeployment(&nefliy.RepoOptions{
+    resp, err = site.ContinuousDeployment(&netlify.RepoOptions{
       Repo: "netlify/netlify-home",
       Provider: "github",
       Dir: "_site",
       Cmd: "gulp build",
       Branch: "master",
-      DeployKeyId: deployKey.Id
+      DeployKey: deployKey,
     })
-    if err != nil {
+    if err == nil {
       // Now make sure to add this URL as a POST webhook to your
       // repository:
       site.DeployHook
     }
 
+    // Trigger a new build from the configured repository
+    build, resp, err := site.Builds.Create(&netlify.BuildOptions{ClearCache: true})
+
+    // Wait for the build to produce a deploy
+    deploy, err := build.WaitForDeploy(context.Background())
+
+    // Split the traffic of the site between the deploys of two branches
+    splitTest, resp, err := site.SplitTests.Create(netlify.SplitTestWeights{
+      "master": 80,
+      "redesign": 20,
+    })
+    resp, err = splitTest.Enable()
+
 
     // Deleting a site
     resp, err := site.Destroy()
@@ -0,0 +1,93 @@
+package netlify
+
+import (
+	"context"
+	"net"
+	"strings"
+)
+
+// NetlifyLoadBalancerIPs are the addresses apex domains should point to
+// when they can't use a CNAME record
+var NetlifyLoadBalancerIPs = []string{"75.2.60.5", "104.198.14.52"}
+
+// Resolver looks up DNS records. *net.Resolver implements it, tests can
+// set Client.Resolver to a fake.
+type Resolver interface {
+	LookupCNAME(ctx context.Context, host string) (string, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// DomainCheck is the result of checking the DNS of a single domain
+type DomainCheck struct {
+	Domain string
+
+	// Target of the CNAME record for the domain, if any
+	CNAME string
+
+	// Addresses the domain resolves to
+	Addresses []string
+
+	// Pointed is true when the domain is correctly pointed at the site
+	Pointed bool
+
+	// Error from resolving the domain
+	Error error
+}
+
+// CheckDomains resolves the custom domain and domain aliases of a site and
+// reports whether each of them is pointed at netlify, either with a CNAME
+// to the netlify subdomain of the site or with the load balancer IPs.
+func (site *Site) CheckDomains(ctx context.Context) []DomainCheck {
+	domains := site.DomainAliases
+	if site.CustomDomain != "" {
+		domains = append([]string{site.CustomDomain}, domains...)
+	}
+
+	resolver := site.client.Resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	checks := []DomainCheck{}
+	for _, domain := range domains {
+		checks = append(checks, site.checkDomain(ctx, resolver, domain))
+	}
+	return checks
+}
+
+func (site *Site) checkDomain(ctx context.Context, resolver Resolver, domain string) DomainCheck {
+	check := DomainCheck{Domain: domain}
+
+	cname, err := resolver.LookupCNAME(ctx, domain)
+	if err != nil {
+		check.Error = err
+		return check
+	}
+	cname = strings.ToLower(strings.TrimSuffix(cname, "."))
+	if cname != strings.ToLower(domain) {
+		check.CNAME = cname
+		if site.isNetlifyHostname(cname) {
+			check.Pointed = true
+			return check
+		}
+	}
+
+	check.Addresses, check.Error = resolver.LookupHost(ctx, domain)
+	for _, address := range check.Addresses {
+		for _, ip := range NetlifyLoadBalancerIPs {
+			if address == ip {
+				check.Pointed = true
+			}
+		}
+	}
+	return check
+}
+
+func (site *Site) isNetlifyHostname(hostname string) bool {
+	for _, suffix := range []string{".netlify.com", ".netlify.app"} {
+		if hostname == strings.ToLower(site.Name)+suffix || hostname == "apex-loadbalancer"+suffix {
+			return true
+		}
+	}
+	return false
+}
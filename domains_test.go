@@ -0,0 +1,60 @@
+package netlify
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type fakeResolver struct {
+	cnames map[string]string
+	hosts  map[string][]string
+}
+
+func (r *fakeResolver) LookupCNAME(ctx context.Context, host string) (string, error) {
+	if cname, ok := r.cnames[host]; ok {
+		return cname, nil
+	}
+	if _, ok := r.hosts[host]; ok {
+		return host + ".", nil
+	}
+	return "", errors.New("no such host")
+}
+
+func (r *fakeResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	if addresses, ok := r.hosts[host]; ok {
+		return addresses, nil
+	}
+	return nil, errors.New("no such host")
+}
+
+func TestSite_CheckDomains(t *testing.T) {
+	resolver := &fakeResolver{
+		cnames: map[string]string{
+			"www.example.com":  "my-site.netlify.com.",
+			"blog.example.com": "ghs.example.net.",
+		},
+		hosts: map[string][]string{
+			"example.com":      {"75.2.60.5"},
+			"blog.example.com": {"10.0.0.1"},
+		},
+	}
+	client := NewClient(&Config{Resolver: resolver})
+
+	site := &Site{
+		Name:          "my-site",
+		CustomDomain:  "www.example.com",
+		DomainAliases: []string{"example.com", "blog.example.com", "missing.example.com"},
+	}
+	site.setClient(client)
+
+	pointed := []bool{}
+	for _, check := range site.CheckDomains(context.Background()) {
+		pointed = append(pointed, check.Pointed)
+	}
+
+	if expected := []bool{true, true, false, false}; !reflect.DeepEqual(pointed, expected) {
+		t.Errorf("Expected domains to be pointed %v, got %v", expected, pointed)
+	}
+}
@@ -0,0 +1,353 @@
+package netlify
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Deploy contexts environment variable values can be scoped to
+const (
+	EnvContextAll           = "all"
+	EnvContextProduction    = "production"
+	EnvContextDeployPreview = "deploy-preview"
+	EnvContextBranchDeploy  = "branch-deploy"
+	EnvContextBranch        = "branch"
+	EnvContextDev           = "dev"
+)
+
+// EnvVar is an environment variable available to the builds and functions of a site
+type EnvVar struct {
+	Key      string        `json:"key"`
+	Scopes   []string      `json:"scopes,omitempty"`
+	Values   []EnvVarValue `json:"values"`
+	IsSecret bool          `json:"is_secret"`
+
+	UpdatedAt Timestamp `json:"updated_at,omitempty"`
+}
+
+// EnvVarValue is the value of an environment variable in a specific deploy context
+type EnvVarValue struct {
+	Id    string `json:"id,omitempty"`
+	Value string `json:"value"`
+
+	// One of the EnvContext constants
+	Context string `json:"context"`
+
+	// Name of the branch when Context is EnvContextBranch
+	ContextParameter string `json:"context_parameter,omitempty"`
+}
+
+// EnvVarOptions selects the deploy context for EnvVars.Set and EnvVars.Unset
+type EnvVarOptions struct {
+	// Deploy context of the value, defaults to EnvContextAll
+	Context string
+
+	// Branch name, required when Context is EnvContextBranch
+	Branch string
+
+	// Mark the variable as secret. Secret values can't be read back through the API.
+	Secret bool
+}
+
+// EnvVarsService is used to access all EnvVar related API methods
+type EnvVarsService struct {
+	site   *Site
+	client *Client
+}
+
+func (o *EnvVarOptions) context() (string, string, error) {
+	if o == nil || o.Context == "" {
+		return EnvContextAll, "", nil
+	}
+	if o.Context == EnvContextBranch && o.Branch == "" {
+		return "", "", errors.New("A branch is required for the branch deploy context")
+	}
+	if o.Context != EnvContextBranch {
+		return o.Context, "", nil
+	}
+	return o.Context, o.Branch, nil
+}
+
+func (s *EnvVarsService) apiPath(key string) (string, *RequestOptions, error) {
+	if s.site.AccountSlug == "" {
+		return "", nil, errors.New("Cannot manage environment variables without the account of the site, reload the site first")
+	}
+	params := &url.Values{"site_id": []string{s.site.Id}}
+	return path.Join("/accounts", s.site.AccountSlug, "env", key), &RequestOptions{QueryParams: params}, nil
+}
+
+// List all environment variables of the site
+func (s *EnvVarsService) List() ([]EnvVar, *Response, error) {
+	apiPath, options, err := s.apiPath("")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	envVars := new([]EnvVar)
+
+	resp, err := s.client.Request("GET", apiPath, options, envVars)
+
+	return *envVars, resp, err
+}
+
+// Get a single environment variable with its values for all contexts
+func (s *EnvVarsService) Get(key string) (*EnvVar, *Response, error) {
+	apiPath, options, err := s.apiPath(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	envVar := &EnvVar{}
+
+	resp, err := s.client.Request("GET", apiPath, options, envVar)
+
+	return envVar, resp, err
+}
+
+// Set the value of an environment variable for the deploy context in options.
+// Only the value for that context is sent, the values for other contexts are
+// left untouched. The variable is created if it doesn't exist yet.
+//
+// Secret only applies when the variable is created, an existing variable
+// keeps its secret flag.
+func (s *EnvVarsService) Set(key, value string, envOptions *EnvVarOptions) (*EnvVar, *Response, error) {
+	context, branch, err := envOptions.context()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	apiPath, options, err := s.apiPath(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	envValue := EnvVarValue{Value: value, Context: context, ContextParameter: branch}
+	options.JsonBody = &envValue
+
+	envVar := &EnvVar{}
+	resp, err := s.client.Request("PATCH", apiPath, options, envVar)
+	if !isNotFound(err) {
+		return envVar, resp, err
+	}
+
+	envVar = &EnvVar{Key: key, Values: []EnvVarValue{envValue}}
+	if envOptions != nil && envOptions.Secret {
+		envVar.IsSecret = true
+	}
+	return s.create(envVar)
+}
+
+func (s *EnvVarsService) create(envVar *EnvVar) (*EnvVar, *Response, error) {
+	apiPath, options, err := s.apiPath("")
+	if err != nil {
+		return nil, nil, err
+	}
+	options.JsonBody = []*EnvVar{envVar}
+
+	created := new([]EnvVar)
+
+	resp, err := s.client.Request("POST", apiPath, options, created)
+	if err == nil && len(*created) > 0 {
+		envVar = &(*created)[0]
+	}
+
+	return envVar, resp, err
+}
+
+// Unset removes the value of an environment variable for the deploy context
+// in options, leaving the values for other contexts untouched. The variable is
+// removed when it has no other values. With nil options the variable is
+// removed for all contexts.
+func (s *EnvVarsService) Unset(key string, envOptions *EnvVarOptions) (*Response, error) {
+	apiPath, options, err := s.apiPath(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if envOptions != nil {
+		context, branch, err := envOptions.context()
+		if err != nil {
+			return nil, err
+		}
+
+		envVar, resp, err := s.Get(key)
+		if err != nil {
+			return resp, err
+		}
+
+		envValue := envVar.contextValue(context, branch)
+		if envValue == nil {
+			return resp, nil
+		}
+		if len(envVar.Values) > 1 {
+			apiPath = path.Join(apiPath, "value", envValue.Id)
+		}
+	}
+
+	resp, err := s.client.Request("DELETE", apiPath, options, nil)
+	if resp != nil && resp.Body != nil {
+		resp.Body.Close()
+	}
+	return resp, err
+}
+
+// Value returns the value of the variable for a deploy context, falling back
+// to the value for all contexts
+func (e *EnvVar) Value(context, branch string) (string, bool) {
+	if v := e.contextValue(context, branch); v != nil {
+		return v.Value, true
+	}
+	if v := e.contextValue(EnvContextAll, ""); v != nil {
+		return v.Value, true
+	}
+	return "", false
+}
+
+// contextValue returns the value set for exactly this deploy context
+func (e *EnvVar) contextValue(context, branch string) *EnvVarValue {
+	for i, v := range e.Values {
+		if v.Context == context && v.ContextParameter == branch {
+			return &e.Values[i]
+		}
+	}
+	return nil
+}
+
+// EnvSyncPlan lists the changes needed to make the environment variables of
+// a site match a set of local values
+type EnvSyncPlan struct {
+	Added   []string
+	Changed []string
+	Removed []string
+
+	values  map[string]string
+	options *EnvVarOptions
+}
+
+// Empty is true when the site is already in sync
+func (p *EnvSyncPlan) Empty() bool {
+	return len(p.Added) == 0 && len(p.Changed) == 0 && len(p.Removed) == 0
+}
+
+// PlanSync compares values with the environment variables of the site for
+// the deploy context in options. Variables that are only set on the site are
+// listed as removed when prune is true and they have a value for exactly
+// that context, since ApplySync only unsets values for that context.
+func (s *EnvVarsService) PlanSync(values map[string]string, envOptions *EnvVarOptions, prune bool) (*EnvSyncPlan, *Response, error) {
+	context, branch, err := envOptions.context()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	envVars, resp, err := s.List()
+	if err != nil {
+		return nil, resp, err
+	}
+
+	plan := &EnvSyncPlan{values: values, options: envOptions}
+	current := map[string]bool{}
+	for _, envVar := range envVars {
+		current[envVar.Key] = true
+		value, ok := envVar.Value(context, branch)
+		desired, wanted := values[envVar.Key]
+		switch {
+		case !wanted && prune && envVar.contextValue(context, branch) != nil:
+			plan.Removed = append(plan.Removed, envVar.Key)
+		case wanted && !ok:
+			plan.Added = append(plan.Added, envVar.Key)
+		case wanted && value != desired:
+			plan.Changed = append(plan.Changed, envVar.Key)
+		}
+	}
+	for key := range values {
+		if !current[key] {
+			plan.Added = append(plan.Added, key)
+		}
+	}
+
+	sort.Strings(plan.Added)
+	sort.Strings(plan.Changed)
+	sort.Strings(plan.Removed)
+
+	return plan, resp, nil
+}
+
+// ApplySync applies a plan returned by PlanSync
+func (s *EnvVarsService) ApplySync(plan *EnvSyncPlan) error {
+	for _, keys := range [][]string{plan.Added, plan.Changed} {
+		for _, key := range keys {
+			if _, _, err := s.Set(key, plan.values[key], plan.options); err != nil {
+				return err
+			}
+		}
+	}
+
+	unsetOptions := plan.options
+	if unsetOptions == nil {
+		unsetOptions = &EnvVarOptions{}
+	}
+	for _, key := range plan.Removed {
+		if _, err := s.Unset(key, unsetOptions); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReadDotEnv reads the variables of a .env file
+func ReadDotEnv(filename string) (map[string]string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ParseDotEnv(file)
+}
+
+// ParseDotEnv parses KEY=value lines in the .env format. Blank lines and
+// comments are skipped, an "export " prefix is ignored and values can be
+// single or double quoted.
+func ParseDotEnv(r io.Reader) (map[string]string, error) {
+	values := map[string]string{}
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+		parts := strings.SplitN(line, "=", 2)
+		key := strings.TrimSpace(parts[0])
+		if len(parts) != 2 || key == "" {
+			return nil, fmt.Errorf("Invalid line %d in .env file", n)
+		}
+
+		value := strings.TrimSpace(parts[1])
+		if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+			value = strings.Replace(value[1:len(value)-1], `\n`, "\n", -1)
+			value = strings.Replace(value, `\"`, `"`, -1)
+		} else if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+			value = value[1 : len(value)-1]
+		} else if i := strings.Index(value, " #"); i >= 0 {
+			value = strings.TrimSpace(value[:i])
+		}
+
+		values[key] = value
+	}
+
+	return values, scanner.Err()
+}
+
+func isNotFound(err error) bool {
+	errorResponse, ok := err.(*ErrorResponse)
+	return ok && errorResponse.Response != nil && errorResponse.Response.StatusCode == 404
+}
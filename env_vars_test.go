@@ -0,0 +1,162 @@
+package netlify
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEnvVarsService_Set_Existing(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/accounts/my-team/env/API_URL", func(w http.ResponseWriter, r *http.Request) {
+		if siteId := r.URL.Query().Get("site_id"); siteId != "my-site" {
+			t.Errorf("Expected site_id query parameter my-site, got %v", siteId)
+		}
+
+		testMethod(t, r, "PATCH")
+
+		envValue := &EnvVarValue{}
+		json.NewDecoder(r.Body).Decode(envValue)
+
+		expected := &EnvVarValue{Value: "https://staging.example.com", Context: EnvContextDeployPreview}
+		if !reflect.DeepEqual(envValue, expected) {
+			t.Errorf("Expected only the changed value %v, got %v", expected, envValue)
+		}
+
+		fmt.Fprint(w, `{"key":"API_URL","values":[{"value":"https://api.example.com","context":"all"},{"value":"https://staging.example.com","context":"deploy-preview"}]}`)
+	})
+
+	site := &Site{Id: "my-site", AccountSlug: "my-team"}
+	site.setClient(client)
+
+	_, _, err := site.EnvVars.Set("API_URL", "https://staging.example.com", &EnvVarOptions{Context: EnvContextDeployPreview})
+	if err != nil {
+		t.Errorf("EnvVars.Set returned an error: %v", err)
+	}
+}
+
+func TestEnvVarsService_PlanSync(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/accounts/my-team/env", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[
+			{"key":"SAME","values":[{"value":"1","context":"all"}]},
+			{"key":"CHANGED","values":[{"value":"old","context":"all"}]},
+			{"key":"STALE","values":[{"value":"x","context":"all"}]}
+		]`)
+	})
+
+	site := &Site{Id: "my-site", AccountSlug: "my-team"}
+	site.setClient(client)
+
+	values, err := ParseDotEnv(strings.NewReader("# local settings\nSAME=1\nexport CHANGED=\"new\"\nNEW='value'\n"))
+	if err != nil {
+		t.Fatalf("ParseDotEnv returned an error: %v", err)
+	}
+
+	plan, _, err := site.EnvVars.PlanSync(values, nil, true)
+	if err != nil {
+		t.Fatalf("EnvVars.PlanSync returned an error: %v", err)
+	}
+
+	expected := &EnvSyncPlan{Added: []string{"NEW"}, Changed: []string{"CHANGED"}, Removed: []string{"STALE"}, values: values}
+	if !reflect.DeepEqual(plan, expected) {
+		t.Errorf("Expected plan %+v, got %+v", expected, plan)
+	}
+}
+
+func TestEnvVarsService_Set_New(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/accounts/my-team/env/API_KEY", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		http.Error(w, "Not Found", http.StatusNotFound)
+	})
+
+	mux.HandleFunc("/api/v1/accounts/my-team/env", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		envVars := []EnvVar{}
+		json.NewDecoder(r.Body).Decode(&envVars)
+		if len(envVars) != 1 || !envVars[0].IsSecret || envVars[0].Values[0].Context != EnvContextProduction {
+			t.Errorf("Unexpected env vars: %v", envVars)
+		}
+
+		json.NewEncoder(w).Encode(envVars)
+	})
+
+	site := &Site{Id: "my-site", AccountSlug: "my-team"}
+	site.setClient(client)
+
+	envVar, _, err := site.EnvVars.Set("API_KEY", "secret", &EnvVarOptions{Context: EnvContextProduction, Secret: true})
+	if err != nil {
+		t.Errorf("EnvVars.Set returned an error: %v", err)
+	}
+	if envVar.Key != "API_KEY" {
+		t.Errorf("Unexpected env var: %v", envVar)
+	}
+}
+
+func TestEnvVarsService_Unset_Context(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/accounts/my-team/env/API_URL", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"key":"API_URL","values":[{"id":"all-value","value":"https://api.example.com","context":"all"},{"id":"preview-value","value":"https://old.example.com","context":"deploy-preview"}]}`)
+		default:
+			t.Errorf("The whole variable should not be changed, got %v", r.Method)
+		}
+	})
+
+	deleted := false
+	mux.HandleFunc("/api/v1/accounts/my-team/env/API_URL/value/preview-value", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		deleted = true
+	})
+
+	site := &Site{Id: "my-site", AccountSlug: "my-team"}
+	site.setClient(client)
+
+	if _, err := site.EnvVars.Unset("API_URL", &EnvVarOptions{Context: EnvContextDeployPreview}); err != nil {
+		t.Errorf("EnvVars.Unset returned an error: %v", err)
+	}
+	if !deleted {
+		t.Errorf("Expected the deploy-preview value to be deleted")
+	}
+}
+
+func TestEnvVarsService_PlanSync_Context(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/accounts/my-team/env", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[
+			{"key":"SHARED","values":[{"value":"1","context":"all"}]},
+			{"key":"STALE","values":[{"value":"x","context":"production"}]}
+		]`)
+	})
+
+	site := &Site{Id: "my-site", AccountSlug: "my-team"}
+	site.setClient(client)
+
+	options := &EnvVarOptions{Context: EnvContextProduction}
+	plan, _, err := site.EnvVars.PlanSync(map[string]string{}, options, true)
+	if err != nil {
+		t.Fatalf("EnvVars.PlanSync returned an error: %v", err)
+	}
+
+	if !reflect.DeepEqual(plan.Removed, []string{"STALE"}) {
+		t.Errorf("Expected only values of the production context to be removed, got %v", plan.Removed)
+	}
+}
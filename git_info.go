@@ -0,0 +1,561 @@
+package netlify
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// GitInfo describes the commit a deploy is made from
+type GitInfo struct {
+	Branch        string
+	CommitRef     string
+	CommitMessage string
+	Author        string
+}
+
+// ciEnv is the set of environment variables a CI service exposes the
+// current commit in. The first variable that is set wins. Variables in
+// remoteBranch hold the remote tracking branch, like "origin/main", and are
+// only used when none of the branch variables is set.
+type ciEnv struct {
+	detect        string
+	branch        []string
+	remoteBranch  []string
+	commitRef     []string
+	commitMessage []string
+	author        []string
+}
+
+var ciEnvs = []ciEnv{
+	{detect: "NETLIFY", branch: []string{"BRANCH"}, commitRef: []string{"COMMIT_REF"}},
+	{detect: "GITHUB_ACTIONS", branch: []string{"GITHUB_HEAD_REF", "GITHUB_REF_NAME"}, commitRef: []string{"GITHUB_SHA"}},
+	{
+		detect:        "GITLAB_CI",
+		branch:        []string{"CI_MERGE_REQUEST_SOURCE_BRANCH_NAME", "CI_COMMIT_BRANCH", "CI_COMMIT_REF_NAME"},
+		commitRef:     []string{"CI_COMMIT_SHA"},
+		commitMessage: []string{"CI_COMMIT_MESSAGE"},
+		author:        []string{"CI_COMMIT_AUTHOR"},
+	},
+	{detect: "CIRCLECI", branch: []string{"CIRCLE_BRANCH"}, commitRef: []string{"CIRCLE_SHA1"}},
+	{
+		detect:        "TRAVIS",
+		branch:        []string{"TRAVIS_PULL_REQUEST_BRANCH", "TRAVIS_BRANCH"},
+		commitRef:     []string{"TRAVIS_PULL_REQUEST_SHA", "TRAVIS_COMMIT"},
+		commitMessage: []string{"TRAVIS_COMMIT_MESSAGE"},
+	},
+	{detect: "BITBUCKET_BUILD_NUMBER", branch: []string{"BITBUCKET_BRANCH"}, commitRef: []string{"BITBUCKET_COMMIT"}},
+	{
+		detect:       "JENKINS_URL",
+		branch:       []string{"GIT_LOCAL_BRANCH", "BRANCH_NAME"},
+		remoteBranch: []string{"GIT_BRANCH"},
+		commitRef:    []string{"GIT_COMMIT"},
+	},
+}
+
+// ReadGitInfo returns the branch, commit SHA, commit message and author of
+// the git repository dir is in, without running git.
+//
+// Values exposed by common CI services (Netlify, GitHub Actions, GitLab CI,
+// CircleCI, Travis CI, Bitbucket Pipelines and Jenkins) take precedence,
+// since CI checkouts are often in a detached HEAD state. HEAD is read from
+// the .git directory otherwise, and the message and author are read from the
+// commit object. Branch is empty for a detached HEAD outside of CI. When
+// the CI service exposes the commit, its values are used as is if there's no
+// readable .git directory.
+//
+// The result can be passed to Deploy.DeployDirWithGitInfo:
+//
+//	info, err := netlify.ReadGitInfo(".")
+//	resp, err := deploy.DeployDirWithGitInfo("public", info.Branch, info.CommitRef)
+func ReadGitInfo(dir string) (*GitInfo, error) {
+	info := gitInfoFromEnv()
+
+	repo, err := findGitRepo(dir)
+	if err != nil {
+		if info.CommitRef != "" {
+			return info, nil
+		}
+		return nil, err
+	}
+
+	branch, commitRef, err := repo.head()
+	if err != nil {
+		if info.CommitRef != "" {
+			return info, nil
+		}
+		return nil, err
+	}
+	if info.Branch == "" {
+		info.Branch = branch
+	}
+	if info.CommitRef == "" {
+		info.CommitRef = commitRef
+	}
+
+	if info.CommitRef != "" && (info.CommitMessage == "" || info.Author == "") {
+		// A shallow CI clone might not have the commit, the message and
+		// author are optional
+		if commit, err := repo.readCommit(info.CommitRef); err == nil {
+			if info.CommitMessage == "" {
+				info.CommitMessage = commit.message
+			}
+			if info.Author == "" {
+				info.Author = commit.author
+			}
+		}
+	}
+
+	return info, nil
+}
+
+func gitInfoFromEnv() *GitInfo {
+	info := &GitInfo{}
+	for _, env := range ciEnvs {
+		if os.Getenv(env.detect) == "" {
+			continue
+		}
+		info.Branch = firstEnv(env.branch)
+		if info.Branch == "" {
+			info.Branch = strings.TrimPrefix(firstEnv(env.remoteBranch), "origin/")
+		}
+		info.CommitRef = firstEnv(env.commitRef)
+		info.CommitMessage = firstEnv(env.commitMessage)
+		info.Author = firstEnv(env.author)
+		break
+	}
+
+	info.Branch = strings.TrimPrefix(info.Branch, "refs/heads/")
+	return info
+}
+
+func firstEnv(keys []string) string {
+	for _, key := range keys {
+		if value := os.Getenv(key); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// gitRepo is a .git directory. For worktrees, gitDir has the HEAD of the
+// worktree and commonDir the refs and objects shared with the main repo.
+type gitRepo struct {
+	gitDir    string
+	commonDir string
+}
+
+func findGitRepo(dir string) (*gitRepo, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		gitDir := filepath.Join(dir, ".git")
+		if info, err := os.Stat(gitDir); err == nil {
+			if !info.IsDir() {
+				// Worktrees and submodules have a .git file pointing to the git dir
+				if gitDir, err = readGitDirFile(gitDir); err != nil {
+					return nil, err
+				}
+			}
+			return newGitRepo(gitDir), nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, errors.New("Not a git repository: " + dir)
+		}
+		dir = parent
+	}
+}
+
+func readGitDirFile(file string) (string, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	content := strings.TrimSpace(string(data))
+	if !strings.HasPrefix(content, "gitdir: ") {
+		return "", errors.New("Invalid .git file: " + file)
+	}
+	gitDir := strings.TrimPrefix(content, "gitdir: ")
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(filepath.Dir(file), gitDir)
+	}
+	return gitDir, nil
+}
+
+func newGitRepo(gitDir string) *gitRepo {
+	repo := &gitRepo{gitDir: gitDir, commonDir: gitDir}
+	if data, err := ioutil.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		commonDir := strings.TrimSpace(string(data))
+		if !filepath.IsAbs(commonDir) {
+			commonDir = filepath.Join(gitDir, commonDir)
+		}
+		repo.commonDir = commonDir
+	}
+	return repo
+}
+
+// head returns the current branch and commit. The branch is empty for a
+// detached HEAD and the commit is empty for a branch without commits.
+func (repo *gitRepo) head() (string, string, error) {
+	data, err := ioutil.ReadFile(filepath.Join(repo.gitDir, "HEAD"))
+	if err != nil {
+		return "", "", err
+	}
+	head := strings.TrimSpace(string(data))
+
+	if !strings.HasPrefix(head, "ref: ") {
+		return "", head, nil
+	}
+
+	ref := strings.TrimPrefix(head, "ref: ")
+	commitRef, err := repo.resolveRef(ref)
+	return strings.TrimPrefix(ref, "refs/heads/"), commitRef, err
+}
+
+func (repo *gitRepo) resolveRef(ref string) (string, error) {
+	for _, dir := range []string{repo.gitDir, repo.commonDir} {
+		data, err := ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(ref)))
+		if err == nil {
+			return strings.TrimSpace(string(data)), nil
+		}
+		if !os.IsNotExist(err) {
+			return "", err
+		}
+	}
+
+	file, err := os.Open(filepath.Join(repo.commonDir, "packed-refs"))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "#") || strings.HasPrefix(line, "^") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[1] == ref {
+			return fields[0], nil
+		}
+	}
+	return "", scanner.Err()
+}
+
+type gitCommit struct {
+	author  string
+	message string
+}
+
+func (repo *gitRepo) readCommit(sha string) (*gitCommit, error) {
+	objectType, data, err := repo.readObject(sha)
+	if err != nil {
+		return nil, err
+	}
+	if objectType != gitObjectCommit {
+		return nil, fmt.Errorf("Git object %v is not a commit", sha)
+	}
+
+	commit := &gitCommit{}
+	headers, message := data, []byte{}
+	if i := bytes.Index(data, []byte("\n\n")); i >= 0 {
+		headers, message = data[:i], data[i+2:]
+	}
+	for _, line := range strings.Split(string(headers), "\n") {
+		if strings.HasPrefix(line, "author ") {
+			author := strings.TrimPrefix(line, "author ")
+			// Drop the timestamp and timezone after the email
+			if i := strings.LastIndex(author, ">"); i >= 0 {
+				author = author[:i+1]
+			}
+			commit.author = author
+		}
+	}
+	commit.message = strings.TrimSpace(string(message))
+	return commit, nil
+}
+
+// Git object types, as used in pack files
+const (
+	gitObjectCommit   = 1
+	gitObjectTree     = 2
+	gitObjectBlob     = 3
+	gitObjectTag      = 4
+	gitObjectOfsDelta = 6
+	gitObjectRefDelta = 7
+)
+
+var gitObjectTypes = map[string]int{
+	"commit": gitObjectCommit,
+	"tree":   gitObjectTree,
+	"blob":   gitObjectBlob,
+	"tag":    gitObjectTag,
+}
+
+func (repo *gitRepo) readObject(sha string) (int, []byte, error) {
+	if len(sha) != 40 {
+		return 0, nil, errors.New("Invalid git object id: " + sha)
+	}
+
+	objectType, data, err := repo.readLooseObject(sha)
+	if !os.IsNotExist(err) {
+		return objectType, data, err
+	}
+
+	packs, err := filepath.Glob(filepath.Join(repo.commonDir, "objects", "pack", "*.idx"))
+	if err != nil {
+		return 0, nil, err
+	}
+	id, err := hex.DecodeString(sha)
+	if err != nil {
+		return 0, nil, err
+	}
+	for _, idx := range packs {
+		offset, err := findPackOffset(idx, id)
+		if err != nil {
+			return 0, nil, err
+		}
+		if offset >= 0 {
+			return repo.readPackedObject(strings.TrimSuffix(idx, ".idx")+".pack", offset)
+		}
+	}
+
+	return 0, nil, fmt.Errorf("Git object %v not found", sha)
+}
+
+func (repo *gitRepo) readLooseObject(sha string) (int, []byte, error) {
+	file, err := os.Open(filepath.Join(repo.commonDir, "objects", sha[:2], sha[2:]))
+	if err != nil {
+		return 0, nil, err
+	}
+	defer file.Close()
+
+	data, err := inflate(file)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	i := bytes.IndexByte(data, 0)
+	if i < 0 {
+		return 0, nil, fmt.Errorf("Invalid git object %v", sha)
+	}
+	header := strings.Fields(string(data[:i]))
+	if len(header) != 2 || gitObjectTypes[header[0]] == 0 {
+		return 0, nil, fmt.Errorf("Invalid git object %v", sha)
+	}
+	return gitObjectTypes[header[0]], data[i+1:], nil
+}
+
+// findPackOffset looks up an object in a version 2 pack index and returns
+// its offset in the pack, or -1 if the pack doesn't have it
+func findPackOffset(idx string, id []byte) (int64, error) {
+	data, err := ioutil.ReadFile(idx)
+	if err != nil {
+		return 0, err
+	}
+	if len(data) < 8+256*4 || !bytes.Equal(data[:4], []byte{0xff, 't', 'O', 'c'}) || binary.BigEndian.Uint32(data[4:8]) != 2 {
+		return 0, errors.New("Unsupported git pack index: " + idx)
+	}
+
+	fanout := data[8 : 8+256*4]
+	count := int(binary.BigEndian.Uint32(fanout[255*4:]))
+	start := 0
+	if id[0] > 0 {
+		start = int(binary.BigEndian.Uint32(fanout[(int(id[0])-1)*4:]))
+	}
+	end := int(binary.BigEndian.Uint32(fanout[int(id[0])*4:]))
+
+	names := data[8+256*4:]
+	if len(names) < count*28 {
+		return 0, errors.New("Truncated git pack index: " + idx)
+	}
+	offsets := names[count*24:]
+	largeOffsets := offsets[count*4:]
+
+	i := start + sort.Search(end-start, func(i int) bool {
+		return bytes.Compare(names[(start+i)*20:(start+i+1)*20], id) >= 0
+	})
+	if i == end || !bytes.Equal(names[i*20:(i+1)*20], id) {
+		return -1, nil
+	}
+
+	offset := binary.BigEndian.Uint32(offsets[i*4:])
+	if offset&0x80000000 == 0 {
+		return int64(offset), nil
+	}
+	large := int(offset&0x7fffffff) * 8
+	if len(largeOffsets) < large+8 {
+		return 0, errors.New("Truncated git pack index: " + idx)
+	}
+	return int64(binary.BigEndian.Uint64(largeOffsets[large:])), nil
+}
+
+func (repo *gitRepo) readPackedObject(pack string, offset int64) (int, []byte, error) {
+	file, err := os.Open(pack)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer file.Close()
+
+	return repo.readPackEntry(file, offset)
+}
+
+func (repo *gitRepo) readPackEntry(pack *os.File, offset int64) (int, []byte, error) {
+	reader := bufio.NewReader(io.NewSectionReader(pack, offset, 1<<62))
+
+	b, err := reader.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	objectType := int(b>>4) & 7
+	// The object size is a varint, the inflated data has the same size
+	for b&0x80 != 0 {
+		if b, err = reader.ReadByte(); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	var baseType int
+	var base []byte
+	switch objectType {
+	case gitObjectOfsDelta:
+		if b, err = reader.ReadByte(); err != nil {
+			return 0, nil, err
+		}
+		distance := int64(b & 0x7f)
+		for b&0x80 != 0 {
+			if b, err = reader.ReadByte(); err != nil {
+				return 0, nil, err
+			}
+			distance = ((distance + 1) << 7) | int64(b&0x7f)
+		}
+		baseType, base, err = repo.readPackEntry(pack, offset-distance)
+	case gitObjectRefDelta:
+		id := make([]byte, 20)
+		if _, err := io.ReadFull(reader, id); err != nil {
+			return 0, nil, err
+		}
+		baseType, base, err = repo.readObject(hex.EncodeToString(id))
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+
+	data, err := inflate(reader)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if base == nil {
+		return objectType, data, nil
+	}
+	data, err = applyDelta(base, data)
+	return baseType, data, err
+}
+
+// applyDelta reconstructs an object from its base and a git delta
+func applyDelta(base, delta []byte) ([]byte, error) {
+	invalid := errors.New("Invalid git delta")
+	pos := 0
+	readSize := func() (int, error) {
+		size, shift := 0, uint(0)
+		for {
+			if pos >= len(delta) {
+				return 0, invalid
+			}
+			b := delta[pos]
+			pos++
+			size |= int(b&0x7f) << shift
+			shift += 7
+			if b&0x80 == 0 {
+				return size, nil
+			}
+		}
+	}
+
+	baseSize, err := readSize()
+	if err != nil {
+		return nil, err
+	}
+	if baseSize != len(base) {
+		return nil, invalid
+	}
+	size, err := readSize()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]byte, 0, size)
+	for pos < len(delta) {
+		op := delta[pos]
+		pos++
+
+		if op&0x80 == 0 {
+			// Insert the next op bytes of the delta
+			n := int(op)
+			if n == 0 || pos+n > len(delta) {
+				return nil, invalid
+			}
+			result = append(result, delta[pos:pos+n]...)
+			pos += n
+			continue
+		}
+
+		// Copy from the base, the op bits say which offset and size bytes follow
+		var copyOffset, copySize int
+		for i := uint(0); i < 7; i++ {
+			if op&(1<<i) == 0 {
+				continue
+			}
+			if pos >= len(delta) {
+				return nil, invalid
+			}
+			if i < 4 {
+				copyOffset |= int(delta[pos]) << (8 * i)
+			} else {
+				copySize |= int(delta[pos]) << (8 * (i - 4))
+			}
+			pos++
+		}
+		if copySize == 0 {
+			copySize = 0x10000
+		}
+		if copyOffset+copySize > len(base) {
+			return nil, invalid
+		}
+		result = append(result, base[copyOffset:copyOffset+copySize]...)
+	}
+
+	if len(result) != size {
+		return nil, invalid
+	}
+	return result, nil
+}
+
+func inflate(r io.Reader) ([]byte, error) {
+	reader, err := zlib.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return ioutil.ReadAll(reader)
+}
@@ -0,0 +1,285 @@
+package netlify
+
+import (
+	"bytes"
+	"compress/zlib"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// clearCIEnv unsets the CI environment variables for the duration of a test.
+// The returned func also unsets the variables the test set itself.
+func clearCIEnv() func() {
+	var keys []string
+	for _, env := range ciEnvs {
+		keys = append(keys, env.detect)
+		keys = append(keys, env.branch...)
+		keys = append(keys, env.remoteBranch...)
+		keys = append(keys, env.commitRef...)
+		keys = append(keys, env.commitMessage...)
+		keys = append(keys, env.author...)
+	}
+
+	saved := map[string]string{}
+	for _, key := range keys {
+		if value, ok := os.LookupEnv(key); ok {
+			saved[key] = value
+		}
+		os.Unsetenv(key)
+	}
+	return func() {
+		for _, key := range keys {
+			if value, ok := saved[key]; ok {
+				os.Setenv(key, value)
+			} else {
+				os.Unsetenv(key)
+			}
+		}
+	}
+}
+
+// writeTestRepo creates a .git directory with a single loose commit. HEAD
+// is detached at the commit when head is empty.
+func writeTestRepo(t *testing.T, head string) (string, string) {
+	root, err := ioutil.TempDir("", "netlify-git")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gitDir := filepath.Join(root, ".git")
+
+	commit := []byte("tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+		"author Jane Doe <jane@example.com> 1500000000 +0200\n" +
+		"committer Jane Doe <jane@example.com> 1500000000 +0200\n" +
+		"\nFix the header\n\nWith a longer description\n")
+	object := append([]byte(fmt.Sprintf("commit %d\x00", len(commit))), commit...)
+	sum := sha1.Sum(object)
+	sha := hex.EncodeToString(sum[:])
+
+	compressed := new(bytes.Buffer)
+	writer := zlib.NewWriter(compressed)
+	writer.Write(object)
+	writer.Close()
+
+	os.MkdirAll(filepath.Join(gitDir, "objects", sha[:2]), 0755)
+	os.MkdirAll(filepath.Join(gitDir, "refs", "heads"), 0755)
+	ioutil.WriteFile(filepath.Join(gitDir, "objects", sha[:2], sha[2:]), compressed.Bytes(), 0644)
+	if head == "" {
+		head = sha
+	}
+	ioutil.WriteFile(filepath.Join(gitDir, "HEAD"), []byte(head+"\n"), 0644)
+
+	return root, sha
+}
+
+func TestReadGitInfo(t *testing.T) {
+	defer clearCIEnv()()
+
+	root, sha := writeTestRepo(t, "ref: refs/heads/feature/header")
+	defer os.RemoveAll(root)
+	os.MkdirAll(filepath.Join(root, ".git", "refs", "heads", "feature"), 0755)
+	ioutil.WriteFile(filepath.Join(root, ".git", "refs", "heads", "feature", "header"), []byte(sha+"\n"), 0644)
+	os.MkdirAll(filepath.Join(root, "site", "public"), 0755)
+
+	info, err := ReadGitInfo(filepath.Join(root, "site", "public"))
+	if err != nil {
+		t.Fatalf("ReadGitInfo returned an error: %v", err)
+	}
+
+	expected := GitInfo{
+		Branch:        "feature/header",
+		CommitRef:     sha,
+		CommitMessage: "Fix the header\n\nWith a longer description",
+		Author:        "Jane Doe <jane@example.com>",
+	}
+	if *info != expected {
+		t.Errorf("Expected %v, got %v", expected, *info)
+	}
+}
+
+func TestReadGitInfo_PackedRefs(t *testing.T) {
+	defer clearCIEnv()()
+
+	root, sha := writeTestRepo(t, "ref: refs/heads/master")
+	defer os.RemoveAll(root)
+	packedRefs := "# pack-refs with: peeled fully-peeled sorted\n" +
+		"1111111111111111111111111111111111111111 refs/heads/develop\n" +
+		sha + " refs/heads/master\n" +
+		"^2222222222222222222222222222222222222222\n"
+	ioutil.WriteFile(filepath.Join(root, ".git", "packed-refs"), []byte(packedRefs), 0644)
+
+	info, err := ReadGitInfo(root)
+	if err != nil {
+		t.Fatalf("ReadGitInfo returned an error: %v", err)
+	}
+	if info.Branch != "master" || info.CommitRef != sha {
+		t.Errorf("Unexpected git info: %v", info)
+	}
+}
+
+func TestReadGitInfo_Detached(t *testing.T) {
+	defer clearCIEnv()()
+
+	root, sha := writeTestRepo(t, "")
+	defer os.RemoveAll(root)
+
+	info, err := ReadGitInfo(root)
+	if err != nil {
+		t.Fatalf("ReadGitInfo returned an error: %v", err)
+	}
+	if info.Branch != "" || info.CommitRef != sha || info.CommitMessage == "" {
+		t.Errorf("Unexpected git info: %v", info)
+	}
+}
+
+func TestReadGitInfo_CIEnv(t *testing.T) {
+	defer clearCIEnv()()
+
+	root, sha := writeTestRepo(t, "")
+	defer os.RemoveAll(root)
+
+	os.Setenv("JENKINS_URL", "https://ci.example.com")
+	os.Setenv("GIT_BRANCH", "origin/staging")
+	os.Setenv("GIT_COMMIT", sha)
+
+	info, err := ReadGitInfo(root)
+	if err != nil {
+		t.Fatalf("ReadGitInfo returned an error: %v", err)
+	}
+	if info.Branch != "staging" || info.CommitRef != sha || info.Author != "Jane Doe <jane@example.com>" {
+		t.Errorf("Unexpected git info: %v", info)
+	}
+
+	// Outside of a repository the CI values are used as is
+	dir, _ := ioutil.TempDir("", "netlify-no-git")
+	defer os.RemoveAll(dir)
+
+	info, err = ReadGitInfo(dir)
+	if err != nil {
+		t.Fatalf("ReadGitInfo returned an error: %v", err)
+	}
+	if info.Branch != "staging" || info.CommitRef != sha || info.Author != "" {
+		t.Errorf("Unexpected git info: %v", info)
+	}
+}
+
+func TestReadGitInfo_CIEnv_Unreadable_HEAD(t *testing.T) {
+	defer clearCIEnv()()
+
+	root, sha := writeTestRepo(t, "")
+	defer os.RemoveAll(root)
+	os.Remove(filepath.Join(root, ".git", "HEAD"))
+	os.Mkdir(filepath.Join(root, ".git", "HEAD"), 0755)
+
+	os.Setenv("GITHUB_ACTIONS", "true")
+	os.Setenv("GITHUB_REF_NAME", "origin/feature")
+	os.Setenv("GITHUB_SHA", sha)
+
+	info, err := ReadGitInfo(root)
+	if err != nil {
+		t.Fatalf("ReadGitInfo returned an error: %v", err)
+	}
+	if info.Branch != "origin/feature" || info.CommitRef != sha {
+		t.Errorf("Expected the CI values without stripping the branch, got %v", info)
+	}
+}
+
+func TestReadGitInfo_NoRepo(t *testing.T) {
+	defer clearCIEnv()()
+
+	dir, _ := ioutil.TempDir("", "netlify-no-git")
+	defer os.RemoveAll(dir)
+
+	if _, err := ReadGitInfo(dir); err == nil {
+		t.Errorf("Expected an error outside of a git repository")
+	}
+}
+
+func TestReadGitInfo_Packed(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	defer clearCIEnv()()
+
+	root, err := ioutil.TempDir("", "netlify-git")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	git := func(args ...string) string {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=Jane Doe", "-c", "user.email=jane@example.com"}, args...)...)
+		cmd.Dir = root
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v %s", args, err, out)
+		}
+		return string(bytes.TrimSpace(out))
+	}
+
+	git("init", "-q")
+	git("checkout", "-q", "-b", "main")
+	for i := 0; i < 5; i++ {
+		ioutil.WriteFile(filepath.Join(root, "index.html"), []byte(fmt.Sprintf("Version %d\n%s", i, bytes.Repeat([]byte("Same content\n"), 100))), 0644)
+		git("add", "index.html")
+		git("commit", "-q", "-m", fmt.Sprintf("Release version %d", i))
+	}
+	git("gc", "-q", "--aggressive")
+
+	info, err := ReadGitInfo(root)
+	if err != nil {
+		t.Fatalf("ReadGitInfo returned an error: %v", err)
+	}
+
+	expected := GitInfo{
+		Branch:        "main",
+		CommitRef:     git("rev-parse", "HEAD"),
+		CommitMessage: "Release version 4",
+		Author:        "Jane Doe <jane@example.com>",
+	}
+	if *info != expected {
+		t.Errorf("Expected %v, got %v", expected, *info)
+	}
+
+	commit, err := testGitRepo(t, root).readCommit(git("rev-parse", "HEAD~3"))
+	if err != nil || commit.message != "Release version 1" {
+		t.Errorf("Unexpected commit %v: %v", commit, err)
+	}
+
+	// Similar blobs are stored as deltas of each other in the pack
+	objectType, data, err := testGitRepo(t, root).readObject(git("rev-parse", "HEAD~2:index.html"))
+	if err != nil || objectType != gitObjectBlob || !bytes.HasPrefix(data, []byte("Version 2\n")) {
+		t.Errorf("Unexpected blob %v %q: %v", objectType, data, err)
+	}
+}
+
+func testGitRepo(t *testing.T, root string) *gitRepo {
+	repo, err := findGitRepo(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return repo
+}
+
+func TestApplyDelta(t *testing.T) {
+	base := []byte("Hello, World")
+	// Base size 12, result size 14, copy 7 bytes from 0, insert "Gophers"
+	delta := []byte{12, 14, 0x90, 7, 7, 'G', 'o', 'p', 'h', 'e', 'r', 's'}
+
+	result, err := applyDelta(base, delta)
+	if err != nil {
+		t.Fatalf("applyDelta returned an error: %v", err)
+	}
+	if string(result) != "Hello, Gophers" {
+		t.Errorf("Unexpected result: %q", result)
+	}
+
+	if _, err := applyDelta([]byte("short"), delta); err == nil {
+		t.Errorf("Expected an error for a base of the wrong size")
+	}
+}
@@ -0,0 +1,162 @@
+package netlify
+
+import (
+	"errors"
+	"net/url"
+	"path"
+)
+
+// Types of notification hooks
+const (
+	HookTypeURL                = "url"
+	HookTypeEmail              = "email"
+	HookTypeSlack              = "slack"
+	HookTypeGitHubCommitStatus = "github_commit_status"
+)
+
+// Events a notification hook can be triggered by
+const (
+	HookEventDeployCreated     = "deploy_created"
+	HookEventDeployBuilding    = "deploy_building"
+	HookEventDeployFailed      = "deploy_failed"
+	HookEventDeployLocked      = "deploy_locked"
+	HookEventDeployUnlocked    = "deploy_unlocked"
+	HookEventSubmissionCreated = "submission_created"
+)
+
+// Hook is an outgoing notification sent by netlify when an event
+// happens on a site (ie. a slack message when a deploy fails)
+type Hook struct {
+	Id     string `json:"id"`
+	SiteId string `json:"site_id"`
+
+	// These fields can be updated through the API
+	Type     string                 `json:"type"`
+	Event    string                 `json:"event"`
+	Data     map[string]interface{} `json:"data"`
+	Disabled bool                   `json:"disabled"`
+
+	CreatedAt Timestamp `json:"created_at"`
+	UpdatedAt Timestamp `json:"updated_at"`
+
+	client *Client
+}
+
+// HookType describes a type of hook and the events and data fields it supports
+type HookType struct {
+	Name   string   `json:"name"`
+	Events []string `json:"events"`
+	Fields []struct {
+		Name     string `json:"name"`
+		Title    string `json:"title"`
+		Type     string `json:"type"`
+		Required bool   `json:"required"`
+	} `json:"fields"`
+}
+
+// HooksService is used to access all Hook related API methods
+type HooksService struct {
+	site   *Site
+	client *Client
+}
+
+// Attributes for Hooks.Create
+type HookAttributes struct {
+	Type  string                 `json:"type"`
+	Event string                 `json:"event"`
+	Data  map[string]interface{} `json:"data"`
+}
+
+// hookUpdate always sends disabled, so Update can enable a hook again
+type hookUpdate struct {
+	HookAttributes
+	Disabled bool `json:"disabled"`
+}
+
+func (s *HooksService) siteParams() (*url.Values, error) {
+	if s.site == nil {
+		return nil, errors.New("You can only access hooks for an existing site (site.Hooks)")
+	}
+	return &url.Values{"site_id": []string{s.site.Id}}, nil
+}
+
+// Create a new notification hook for the site. The data depends on the type
+// of the hook, ie. a slack hook needs the "url" of an incoming slack webhook.
+func (s *HooksService) Create(attributes *HookAttributes) (*Hook, *Response, error) {
+	params, err := s.siteParams()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hook := &Hook{client: s.client}
+
+	reqOptions := &RequestOptions{JsonBody: attributes, QueryParams: params}
+
+	resp, err := s.client.Request("POST", "/hooks", reqOptions, hook)
+
+	return hook, resp, err
+}
+
+// List all notification hooks for the site
+func (s *HooksService) List() ([]Hook, *Response, error) {
+	params, err := s.siteParams()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hooks := new([]Hook)
+
+	resp, err := s.client.Request("GET", "/hooks", &RequestOptions{QueryParams: params}, hooks)
+
+	for i := range *hooks {
+		(*hooks)[i].client = s.client
+	}
+
+	return *hooks, resp, err
+}
+
+// Get a specific hook.
+func (s *HooksService) Get(id string) (*Hook, *Response, error) {
+	hook := &Hook{Id: id, client: s.client}
+	resp, err := hook.Reload()
+
+	return hook, resp, err
+}
+
+// Types lists the types of hooks netlify supports and the events they can be used with
+func (s *HooksService) Types() ([]HookType, *Response, error) {
+	types := new([]HookType)
+
+	resp, err := s.client.Request("GET", "/hooks/types", nil, types)
+
+	return *types, resp, err
+}
+
+func (hook *Hook) apiPath() string {
+	return path.Join("/hooks", hook.Id)
+}
+
+// Reload a hook from the API
+func (hook *Hook) Reload() (*Response, error) {
+	if hook.Id == "" {
+		return nil, errors.New("Cannot fetch hook without an ID")
+	}
+	return hook.client.Request("GET", hook.apiPath(), nil, hook)
+}
+
+// Update will update the type, event, data and disabled state of the hook
+func (hook *Hook) Update() (*Response, error) {
+	attributes := HookAttributes{Type: hook.Type, Event: hook.Event, Data: hook.Data}
+	options := &RequestOptions{JsonBody: &hookUpdate{HookAttributes: attributes, Disabled: hook.Disabled}}
+
+	return hook.client.Request("PUT", hook.apiPath(), options, hook)
+}
+
+// Destroy deletes a hook permanently
+func (hook *Hook) Destroy() (*Response, error) {
+	resp, err := hook.client.Request("DELETE", hook.apiPath(), nil, nil)
+	if resp != nil && resp.Body != nil {
+		resp.Body.Close()
+	}
+	return resp, err
+}
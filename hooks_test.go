@@ -0,0 +1,81 @@
+package netlify
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestHooksService_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/hooks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		if siteId := r.URL.Query().Get("site_id"); siteId != "my-site" {
+			t.Errorf("Expected site_id query parameter my-site, got %v", siteId)
+		}
+
+		buf := new(bytes.Buffer)
+		buf.ReadFrom(r.Body)
+
+		expected := `{"type":"email","event":"deploy_failed","data":{"email":"ops@example.com"}}`
+		if expected != strings.TrimSpace(buf.String()) {
+			t.Errorf("Expected JSON: %v\nGot JSON: %v", expected, buf.String())
+		}
+
+		fmt.Fprint(w, `{"id":"my-hook","site_id":"my-site","type":"email","event":"deploy_failed"}`)
+	})
+
+	site := &Site{Id: "my-site"}
+	site.setClient(client)
+
+	hook, _, err := site.Hooks.Create(&HookAttributes{
+		Type:  HookTypeEmail,
+		Event: HookEventDeployFailed,
+		Data:  map[string]interface{}{"email": "ops@example.com"},
+	})
+	if err != nil {
+		t.Errorf("Hooks.Create returned an error: %v", err)
+	}
+
+	if hook.Id != "my-hook" || hook.Event != HookEventDeployFailed {
+		t.Errorf("Unexpected hook returned from Hooks.Create: %v", hook)
+	}
+}
+
+func TestHooksService_List_Without_Site(t *testing.T) {
+	setup()
+	defer teardown()
+
+	if _, _, err := client.Hooks.List(); err == nil {
+		t.Errorf("Expected Hooks.List without a site to return an error")
+	}
+}
+
+func TestHook_Update_Enable(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/hooks/my-hook", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		buf := new(bytes.Buffer)
+		buf.ReadFrom(r.Body)
+
+		expected := `{"type":"slack","event":"deploy_failed","data":null,"disabled":false}`
+		if expected != strings.TrimSpace(buf.String()) {
+			t.Errorf("Expected JSON: %v\nGot JSON: %v", expected, buf.String())
+		}
+
+		fmt.Fprint(w, `{"id":"my-hook","type":"slack","event":"deploy_failed","disabled":false}`)
+	})
+
+	hook := &Hook{Id: "my-hook", Type: HookTypeSlack, Event: HookEventDeployFailed, Disabled: false, client: client}
+	if _, err := hook.Update(); err != nil {
+		t.Errorf("Hook.Update returned an error: %v", err)
+	}
+}
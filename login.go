@@ -0,0 +1,82 @@
+package netlify
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"path"
+
+	oauth "golang.org/x/oauth2"
+)
+
+// Ticket is a pending login, authorized by the user in the browser
+type Ticket struct {
+	Id         string `json:"id"`
+	ClientId   string `json:"client_id"`
+	Authorized bool   `json:"authorized"`
+
+	CreatedAt Timestamp `json:"created_at"`
+}
+
+// Login runs the ticket based login flow used by command line tools. It
+// creates a ticket, calls openURL with the URL the user must visit to
+// authorize it, waits for the authorization and exchanges the ticket for an
+// access token.
+//
+// Login doesn't need an authenticated client. If the client isn't
+// authenticated, it will use the new access token for further requests,
+// with the HttpClient and RequestTimeout it was configured with. Since that
+// replaces the HTTP client, Login must not run concurrently with other
+// requests made with the same client.
+func (c *Client) Login(ctx context.Context, clientID string, openURL func(string)) (*AccessToken, error) {
+	config := c.config
+	if config == nil {
+		config = &Config{}
+	}
+	// Clients that weren't created with NewClient have no config, their HTTP
+	// client is kept as is
+	authenticated := c.authenticated || (c.client != nil && c.config == nil)
+
+	anonymous := *c
+	if anonymous.client == nil {
+		anonymous.client = c.unauthenticatedClient()
+	}
+
+	ticket := &Ticket{}
+	params := &url.Values{"client_id": []string{clientID}}
+	if _, err := anonymous.Request("POST", "/oauth/tickets", &RequestOptions{QueryParams: params}, ticket); err != nil {
+		return nil, err
+	}
+
+	authorizeURL, err := url.Parse(c.authURL)
+	if err != nil {
+		return nil, err
+	}
+	authorizeURL.RawQuery = url.Values{"response_type": []string{"ticket"}, "ticket": []string{ticket.Id}}.Encode()
+	openURL(authorizeURL.String())
+
+	ticketPath := path.Join("/oauth/tickets", ticket.Id)
+	err = poll(ctx, func() (bool, error) {
+		_, err := anonymous.Request("GET", ticketPath, nil, ticket)
+		return ticket.Authorized, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	token := &AccessToken{client: c}
+	if _, err := anonymous.Request("POST", path.Join(ticketPath, "exchange"), nil, token); err != nil {
+		return nil, err
+	}
+	if token.AccessToken == "" {
+		return nil, errors.New("Ticket exchange didn't return an access token")
+	}
+
+	if !authenticated {
+		c.client = config.oauthClient(oauth.StaticTokenSource(&oauth.Token{AccessToken: token.AccessToken}))
+		config.AccessToken = token.AccessToken
+		c.authenticated = true
+	}
+
+	return token, nil
+}
@@ -0,0 +1,152 @@
+package netlify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	oauth "golang.org/x/oauth2"
+)
+
+func TestClient_Login(t *testing.T) {
+	setup()
+	defer teardown()
+
+	deployPollInterval = time.Millisecond
+	defer func() { deployPollInterval = time.Second }()
+
+	mux.HandleFunc("/api/v1/oauth/tickets", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testFormValues(t, r, values{"client_id": "my-cli"})
+		fmt.Fprint(w, `{"id":"my-ticket","client_id":"my-cli"}`)
+	})
+
+	polls := 0
+	mux.HandleFunc("/api/v1/oauth/tickets/my-ticket", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		polls++
+		fmt.Fprintf(w, `{"id":"my-ticket","authorized":%v}`, polls > 1)
+	})
+
+	mux.HandleFunc("/api/v1/oauth/tickets/my-ticket/exchange", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"id":"token-id","access_token":"user-token","user_email":"me@example.com"}`)
+	})
+
+	mux.HandleFunc("/api/v1/sites", func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer user-token" {
+			t.Errorf("Expected request to be authenticated with the new token, got %v", auth)
+		}
+		fmt.Fprint(w, `[]`)
+	})
+
+	client := NewClient(&Config{BaseUrl: server.URL})
+
+	opened := ""
+	token, err := client.Login(context.Background(), "my-cli", func(url string) { opened = url })
+	if err != nil {
+		t.Fatalf("Client.Login returned an error: %v", err)
+	}
+
+	if expected := "https://app.netlify.com/authorize?response_type=ticket&ticket=my-ticket"; opened != expected {
+		t.Errorf("Expected %v to be opened, got %v", expected, opened)
+	}
+	if token.AccessToken != "user-token" {
+		t.Errorf("Expected access token user-token, got %v", token.AccessToken)
+	}
+
+	if _, _, err := client.Sites.List(nil); err != nil {
+		t.Errorf("Sites.List returned an error: %v", err)
+	}
+}
+
+type countingTransport struct {
+	requests int
+}
+
+func (t *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	t.requests++
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestClient_Login_Keeps_Config(t *testing.T) {
+	setup()
+	defer teardown()
+
+	deployPollInterval = time.Millisecond
+	defer func() { deployPollInterval = time.Second }()
+
+	mux.HandleFunc("/api/v1/oauth/tickets", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"my-ticket","authorized":true}`)
+	})
+	mux.HandleFunc("/api/v1/oauth/tickets/my-ticket", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"my-ticket","authorized":true}`)
+	})
+	mux.HandleFunc("/api/v1/oauth/tickets/my-ticket/exchange", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"token-id","access_token":"user-token"}`)
+	})
+	mux.HandleFunc("/api/v1/sites", func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer user-token" {
+			t.Errorf("Expected request to be authenticated with the new token, got %v", auth)
+		}
+		fmt.Fprint(w, `[]`)
+	})
+
+	transport := &countingTransport{}
+	client := NewClient(&Config{
+		BaseUrl:        server.URL,
+		HttpClient:     &http.Client{Transport: transport},
+		RequestTimeout: 30 * time.Second,
+	})
+
+	if _, err := client.Login(context.Background(), "my-cli", func(string) {}); err != nil {
+		t.Fatalf("Client.Login returned an error: %v", err)
+	}
+
+	before := transport.requests
+	if _, _, err := client.Sites.List(nil); err != nil {
+		t.Errorf("Sites.List returned an error: %v", err)
+	}
+	if transport.requests != before+1 {
+		t.Errorf("Expected requests after Login to use the configured transport")
+	}
+	if client.client.Timeout != 30*time.Second {
+		t.Errorf("Expected the request timeout to be kept, got %v", client.client.Timeout)
+	}
+}
+
+func TestClient_Login_Keeps_Credentials(t *testing.T) {
+	setup()
+	defer teardown()
+
+	deployPollInterval = time.Millisecond
+	defer func() { deployPollInterval = time.Second }()
+
+	mux.HandleFunc("/api/v1/oauth/tickets", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"my-ticket","authorized":true}`)
+	})
+	mux.HandleFunc("/api/v1/oauth/tickets/my-ticket", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"my-ticket","authorized":true}`)
+	})
+	mux.HandleFunc("/api/v1/oauth/tickets/my-ticket/exchange", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"token-id","access_token":"user-token"}`)
+	})
+	mux.HandleFunc("/api/v1/sites", func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer app-token" {
+			t.Errorf("Expected request to keep the credentials of the client, got %v", auth)
+		}
+		fmt.Fprint(w, `[]`)
+	})
+
+	ts := oauth.StaticTokenSource(&oauth.Token{AccessToken: "app-token"})
+	client := NewClientWithTokenSource(&Config{BaseUrl: server.URL}, ts)
+
+	if _, err := client.Login(context.Background(), "my-cli", func(string) {}); err != nil {
+		t.Fatalf("Client.Login returned an error: %v", err)
+	}
+	if _, _, err := client.Sites.List(nil); err != nil {
+		t.Errorf("Sites.List returned an error: %v", err)
+	}
+}
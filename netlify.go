@@ -21,6 +21,7 @@ import (
 const (
 	libraryVersion = "0.1"
 	defaultBaseURL = "https://api.netlify.com"
+	defaultAuthURL = "https://app.netlify.com/authorize"
 	apiVersion     = "v1"
 
 	userAgent = "netlify-go/" + libraryVersion
@@ -33,8 +34,19 @@ const (
 type Config struct {
 	AccessToken string
 
+	// TokenSource provides access tokens, for tokens that can expire.
+	// Takes precedence over AccessToken.
+	TokenSource oauth.TokenSource
+
+	// OAuth2 application credentials, used by AuthCodeURL, Exchange and
+	// ClientCredentialsTokenSource
 	ClientId     string
 	ClientSecret string
+	RedirectUrl  string
+
+	// AuthUrl is the page users authorize an application on, defaults to
+	// https://app.netlify.com/authorize
+	AuthUrl string
 
 	BaseUrl   string
 	UserAgent string
@@ -43,6 +55,12 @@ type Config struct {
 	RequestTimeout time.Duration
 
 	MaxConcurrentUploads int
+
+	// Resolver used by Site.CheckDomains, defaults to net.DefaultResolver
+	Resolver Resolver
+
+	// Validate _redirects, _headers and netlify.toml before deploying a directory
+	ValidateConfigFiles bool
 }
 
 func (c *Config) Token() (*oauth.Token, error) {
@@ -51,8 +69,13 @@ func (c *Config) Token() (*oauth.Token, error) {
 
 // The netlify Client
 type Client struct {
-	client *http.Client
-	log    *logrus.Entry
+	client  *http.Client
+	config  *Config
+	log     *logrus.Entry
+	authURL string
+
+	// authenticated is set when the client was created with credentials
+	authenticated bool
 
 	BaseUrl   *url.URL
 	UserAgent string
@@ -60,8 +83,16 @@ type Client struct {
 	Sites      *SitesService
 	Deploys    *DeploysService
 	DeployKeys *DeployKeysService
+	Hooks      *HooksService
+	DNSZones   *DNSZonesService
+
+	AccessTokens *AccessTokensService
 
 	MaxConcurrentUploads int
+
+	Resolver Resolver
+
+	ValidateConfigFiles bool
 }
 
 // netlify API Response.
@@ -118,19 +149,25 @@ func (o *ListOptions) toQueryParamsMap() *url.Values {
 func NewClient(config *Config) *Client {
 	client := &Client{}
 
+	clientConfig := *config
+	client.config = &clientConfig
+
 	if config.BaseUrl != "" {
 		client.BaseUrl, _ = url.Parse(config.BaseUrl)
 	} else {
 		client.BaseUrl, _ = url.Parse(defaultBaseURL)
 	}
 
+	client.authURL = config.OAuth2Config().Endpoint.AuthURL
+
+	client.authenticated = config.TokenSource != nil || config.AccessToken != ""
+
 	if config.HttpClient != nil {
 		client.client = config.HttpClient
+	} else if config.TokenSource != nil {
+		client.client = config.oauthClient(config.TokenSource)
 	} else if config.AccessToken != "" {
-		client.client = oauth.NewClient(oauth.NoContext, config)
-		if config.RequestTimeout > 0 {
-			client.client.Timeout = config.RequestTimeout
-		}
+		client.client = config.oauthClient(config)
 	}
 
 	if &config.UserAgent != nil {
@@ -145,12 +182,19 @@ func NewClient(config *Config) *Client {
 		client.MaxConcurrentUploads = DefaultMaxConcurrentUploads
 	}
 
+	client.Resolver = config.Resolver
+	client.ValidateConfigFiles = config.ValidateConfigFiles
+
 	log := logrus.New()
 	log.Out = ioutil.Discard
 	client.log = logrus.NewEntry(log)
 
 	client.Sites = &SitesService{client: client}
 	client.Deploys = &DeploysService{client: client}
+	client.DeployKeys = &DeployKeysService{client: client}
+	client.Hooks = &HooksService{client: client}
+	client.DNSZones = &DNSZonesService{client: client}
+	client.AccessTokens = &AccessTokensService{client: client}
 
 	return client
 }
@@ -164,7 +208,7 @@ func (c *Client) SetLogger(log *logrus.Entry) {
 
 func (c *Client) newRequest(method, apiPath string, options *RequestOptions) (*http.Request, error) {
 	if c.client == nil {
-		return nil, errors.New("Client has not been authenticated")
+		return nil, ErrNotAuthenticated
 	}
 
 	urlPath := path.Join("api", apiVersion, apiPath)
@@ -364,3 +408,13 @@ func (r *Response) populatePageValues() {
 		}
 	}
 }
+
+// String returns a pointer to s, for setting optional fields like the ones in SiteUpdate
+func String(s string) *string {
+	return &s
+}
+
+// Bool returns a pointer to b, for setting optional fields like the ones in SiteUpdate
+func Bool(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,99 @@
+package netlify
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	oauth "golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// NewClientWithTokenSource returns a new netlify API client that authenticates
+// with tokens from ts. Use it to act on behalf of a user with a token from
+// Exchange, the token will be refreshed automatically when it expires.
+//
+// When the config has a HttpClient, it's used as the transport for the
+// authenticated requests.
+func NewClientWithTokenSource(config *Config, ts oauth.TokenSource) *Client {
+	client := NewClient(config)
+	client.client = config.oauthClient(ts)
+	client.authenticated = true
+	return client
+}
+
+// OAuth2Config returns the configuration of the OAuth2 application
+func (c *Config) OAuth2Config() *oauth.Config {
+	authURL := c.AuthUrl
+	if authURL == "" {
+		authURL = defaultAuthURL
+	}
+
+	return &oauth.Config{
+		ClientID:     c.ClientId,
+		ClientSecret: c.ClientSecret,
+		RedirectURL:  c.RedirectUrl,
+		Endpoint: oauth.Endpoint{
+			AuthURL:  authURL,
+			TokenURL: c.tokenURL(),
+		},
+	}
+}
+
+// AuthCodeURL returns the URL to send users to for authorizing the
+// application. The state is passed back to the RedirectUrl and should be
+// checked to protect against CSRF.
+func (c *Config) AuthCodeURL(state string) string {
+	return c.OAuth2Config().AuthCodeURL(state)
+}
+
+// Exchange trades the authorization code passed to the RedirectUrl for an access token
+func (c *Config) Exchange(ctx context.Context, code string) (*oauth.Token, error) {
+	return c.OAuth2Config().Exchange(c.oauthContext(ctx), code)
+}
+
+// RefreshToken returns a new access token for an expired token
+func (c *Config) RefreshToken(ctx context.Context, token *oauth.Token) (*oauth.Token, error) {
+	expired := &oauth.Token{RefreshToken: token.RefreshToken}
+	return c.OAuth2Config().TokenSource(c.oauthContext(ctx), expired).Token()
+}
+
+// RefreshingTokenSource returns a TokenSource that refreshes token when it expires
+func (c *Config) RefreshingTokenSource(ctx context.Context, token *oauth.Token) oauth.TokenSource {
+	return c.OAuth2Config().TokenSource(c.oauthContext(ctx), token)
+}
+
+// ClientCredentialsTokenSource returns a TokenSource that authenticates as
+// the application itself, using the client credentials flow
+func (c *Config) ClientCredentialsTokenSource(ctx context.Context) oauth.TokenSource {
+	config := &clientcredentials.Config{
+		ClientID:     c.ClientId,
+		ClientSecret: c.ClientSecret,
+		TokenURL:     c.tokenURL(),
+	}
+	return config.TokenSource(c.oauthContext(ctx))
+}
+
+func (c *Config) tokenURL() string {
+	baseURL := c.BaseUrl
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	return strings.TrimSuffix(baseURL, "/") + "/oauth/token"
+}
+
+// oauthContext makes the oauth2 package use the configured HttpClient
+func (c *Config) oauthContext(ctx context.Context) context.Context {
+	if c.HttpClient != nil {
+		return context.WithValue(ctx, oauth.HTTPClient, c.HttpClient)
+	}
+	return ctx
+}
+
+func (c *Config) oauthClient(ts oauth.TokenSource) *http.Client {
+	client := oauth.NewClient(c.oauthContext(context.Background()), ts)
+	if c.RequestTimeout > 0 {
+		client.Timeout = c.RequestTimeout
+	}
+	return client
+}
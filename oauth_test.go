@@ -0,0 +1,59 @@
+package netlify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	oauth "golang.org/x/oauth2"
+)
+
+func TestConfig_AuthCodeURL(t *testing.T) {
+	config := &Config{ClientId: "my-client", RedirectUrl: "https://example.com/callback"}
+
+	u, err := url.Parse(config.AuthCodeURL("my-state"))
+	if err != nil {
+		t.Fatalf("AuthCodeURL returned an invalid URL: %v", err)
+	}
+
+	query := u.Query()
+	if u.Host != "app.netlify.com" || query.Get("client_id") != "my-client" || query.Get("state") != "my-state" ||
+		query.Get("redirect_uri") != "https://example.com/callback" || query.Get("response_type") != "code" {
+		t.Errorf("Unexpected authorization URL: %v", u)
+	}
+}
+
+func TestConfig_Exchange(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		if r.FormValue("code") != "my-code" || r.FormValue("grant_type") != "authorization_code" {
+			t.Errorf("Unexpected token request: %v", r.Form)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"user-token","token_type":"Bearer","refresh_token":"refresh"}`)
+	})
+
+	mux.HandleFunc("/api/v1/sites", func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer user-token" {
+			t.Errorf("Expected request to be authenticated with the exchanged token, got %v", auth)
+		}
+		fmt.Fprint(w, `[]`)
+	})
+
+	config := &Config{BaseUrl: server.URL, ClientId: "my-client", ClientSecret: "secret"}
+
+	token, err := config.Exchange(context.Background(), "my-code")
+	if err != nil {
+		t.Fatalf("Exchange returned an error: %v", err)
+	}
+
+	client := NewClientWithTokenSource(config, oauth.StaticTokenSource(token))
+	if _, _, err := client.Sites.List(nil); err != nil {
+		t.Errorf("Sites.List returned an error: %v", err)
+	}
+}
@@ -0,0 +1,313 @@
+package netlify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// RepoProvider adds the deploy key and the deploy hook of a site to a
+// repository hosted with a git provider
+type RepoProvider interface {
+	// Name of the provider, used as the RepoOptions Provider
+	Name() string
+
+	// AddDeployKey gives a read only public key access to the repo
+	AddDeployKey(ctx context.Context, repo, title, publicKey string) error
+
+	// AddWebhook makes the repo POST to the url on pushes and pull requests
+	AddWebhook(ctx context.Context, repo, url string) error
+}
+
+const (
+	deployKeyTitle     = "Netlify"
+	webhookDescription = "Netlify deploys"
+)
+
+// ConnectRepo sets up continuous deployment for a site with a repo from a
+// RepoProvider. It creates a deploy key unless the repoOptions already has
+// one, adds it to the repo, configures the site and adds the site's deploy
+// hook as a webhook to the repo. A DeployKeyID without a DeployKey is
+// fetched to add its public key to the repo. The repoOptions aren't changed.
+//
+// A deploy key created by ConnectRepo is deleted again when the repo can't
+// be configured, but it's left on the repo. When only adding the webhook
+// fails, the site is connected but isn't deployed on pushes until the
+// webhook is added, so add site.DeployHook to the repo or call ConnectRepo
+// again.
+func (site *Site) ConnectRepo(ctx context.Context, provider RepoProvider, repoOptions *RepoOptions) (*Response, error) {
+	options := *repoOptions
+	if options.Provider == "" {
+		options.Provider = provider.Name()
+	}
+
+	var created *DeployKey
+	switch {
+	case options.DeployKey != nil:
+	case options.DeployKeyID != "":
+		deployKey, resp, err := site.DeployKeys.Get(options.DeployKeyID)
+		if err != nil {
+			return resp, err
+		}
+		options.DeployKey = deployKey
+	default:
+		deployKey, resp, err := site.DeployKeys.Create()
+		if err != nil {
+			return resp, err
+		}
+		options.DeployKey, created = deployKey, deployKey
+	}
+
+	cleanup := func() {
+		if created != nil {
+			created.Destroy()
+		}
+	}
+
+	if err := provider.AddDeployKey(ctx, options.Repo, deployKeyTitle, options.DeployKey.PublicKey); err != nil {
+		cleanup()
+		return nil, err
+	}
+
+	resp, err := site.ContinuousDeployment(&options)
+	if err != nil {
+		cleanup()
+		return resp, err
+	}
+
+	if site.DeployHook == "" {
+		return resp, errors.New("Site has no deploy hook to add to the repo")
+	}
+
+	return resp, provider.AddWebhook(ctx, options.Repo, site.DeployHook)
+}
+
+// GitHubProvider adds deploy keys and webhooks with the GitHub API
+type GitHubProvider struct {
+	// Personal access token or OAuth token with the repo scope
+	Token string
+
+	// Defaults to https://api.github.com, set for GitHub Enterprise
+	BaseUrl string
+
+	// Defaults to http.DefaultClient
+	HttpClient *http.Client
+}
+
+// Name returns "github"
+func (g *GitHubProvider) Name() string {
+	return "github"
+}
+
+// AddDeployKey adds a read only deploy key to a repo, repo is owner/name
+func (g *GitHubProvider) AddDeployKey(ctx context.Context, repo, title, publicKey string) error {
+	body := map[string]interface{}{"title": title, "key": publicKey, "read_only": true}
+	return g.post(ctx, "/repos/"+repo+"/keys", body)
+}
+
+// AddWebhook adds a webhook for pushes and pull requests to a repo
+func (g *GitHubProvider) AddWebhook(ctx context.Context, repo, hookURL string) error {
+	body := map[string]interface{}{
+		"name":   "web",
+		"active": true,
+		"events": []string{"push", "pull_request"},
+		"config": map[string]string{"url": hookURL, "content_type": "json"},
+	}
+	return g.post(ctx, "/repos/"+repo+"/hooks", body)
+}
+
+func (g *GitHubProvider) post(ctx context.Context, path string, body interface{}) error {
+	return postToProvider(ctx, g.HttpClient, providerUrl(g.BaseUrl, "https://api.github.com", path), body, func(req *http.Request) {
+		req.Header.Set("Authorization", "token "+g.Token)
+		req.Header.Set("Accept", "application/vnd.github.v3+json")
+	})
+}
+
+// GitLabProvider adds deploy keys and webhooks with the GitLab API
+type GitLabProvider struct {
+	// Personal access token with the api scope
+	Token string
+
+	// Defaults to https://gitlab.com/api/v4, set for self hosted GitLab
+	BaseUrl string
+
+	// Defaults to http.DefaultClient
+	HttpClient *http.Client
+}
+
+// Name returns "gitlab"
+func (g *GitLabProvider) Name() string {
+	return "gitlab"
+}
+
+// AddDeployKey adds a read only deploy key to a project, repo is the
+// namespaced path of the project
+func (g *GitLabProvider) AddDeployKey(ctx context.Context, repo, title, publicKey string) error {
+	body := map[string]interface{}{"title": title, "key": publicKey, "can_push": false}
+	return g.post(ctx, g.projectPath(repo)+"/deploy_keys", body)
+}
+
+// AddWebhook adds a webhook for pushes and merge requests to a project
+func (g *GitLabProvider) AddWebhook(ctx context.Context, repo, hookURL string) error {
+	body := map[string]interface{}{"url": hookURL, "push_events": true, "merge_requests_events": true}
+	return g.post(ctx, g.projectPath(repo)+"/hooks", body)
+}
+
+func (g *GitLabProvider) projectPath(repo string) string {
+	return "/projects/" + url.PathEscape(repo)
+}
+
+func (g *GitLabProvider) post(ctx context.Context, path string, body interface{}) error {
+	return postToProvider(ctx, g.HttpClient, providerUrl(g.BaseUrl, "https://gitlab.com/api/v4", path), body, func(req *http.Request) {
+		req.Header.Set("Private-Token", g.Token)
+	})
+}
+
+// BitbucketProvider adds deploy keys and webhooks with the Bitbucket API
+type BitbucketProvider struct {
+	// Username and app password with the repository admin and webhook permissions
+	Username    string
+	AppPassword string
+
+	// Defaults to https://api.bitbucket.org/2.0
+	BaseUrl string
+
+	// Defaults to http.DefaultClient
+	HttpClient *http.Client
+}
+
+// Name returns "bitbucket"
+func (b *BitbucketProvider) Name() string {
+	return "bitbucket"
+}
+
+// AddDeployKey adds a deploy key to a repo, repo is workspace/name
+func (b *BitbucketProvider) AddDeployKey(ctx context.Context, repo, title, publicKey string) error {
+	body := map[string]interface{}{"label": title, "key": publicKey}
+	return b.post(ctx, "/repositories/"+repo+"/deploy-keys", body)
+}
+
+// AddWebhook adds a webhook for pushes and pull requests to a repo
+func (b *BitbucketProvider) AddWebhook(ctx context.Context, repo, hookURL string) error {
+	body := map[string]interface{}{
+		"description": webhookDescription,
+		"url":         hookURL,
+		"active":      true,
+		"events":      []string{"repo:push", "pullrequest:created", "pullrequest:updated"},
+	}
+	return b.post(ctx, "/repositories/"+repo+"/hooks", body)
+}
+
+func (b *BitbucketProvider) post(ctx context.Context, path string, body interface{}) error {
+	return postToProvider(ctx, b.HttpClient, providerUrl(b.BaseUrl, "https://api.bitbucket.org/2.0", path), body, func(req *http.Request) {
+		req.SetBasicAuth(b.Username, b.AppPassword)
+	})
+}
+
+func providerUrl(baseUrl, defaultUrl, path string) string {
+	if baseUrl == "" {
+		baseUrl = defaultUrl
+	}
+	return strings.TrimSuffix(baseUrl, "/") + path
+}
+
+func postToProvider(ctx context.Context, httpClient *http.Client, url string, body interface{}, authenticate func(*http.Request)) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	authenticate(req)
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("Request to %v failed with %v: %s", url, resp.Status, message)
+	}
+	return nil
+}
+
+// FakeRepoProvider is a RepoProvider that records deploy keys and webhooks
+// in memory, for testing code that connects repos
+type FakeRepoProvider struct {
+	// Defaults to "github"
+	ProviderName string
+
+	// Returned by AddDeployKey and AddWebhook when set
+	Err error
+
+	mutex      sync.Mutex
+	deployKeys map[string][]string
+	webhooks   map[string][]string
+}
+
+// Name returns the ProviderName
+func (f *FakeRepoProvider) Name() string {
+	if f.ProviderName == "" {
+		return "github"
+	}
+	return f.ProviderName
+}
+
+// AddDeployKey records the public key for the repo
+func (f *FakeRepoProvider) AddDeployKey(ctx context.Context, repo, title, publicKey string) error {
+	if f.Err != nil {
+		return f.Err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.deployKeys == nil {
+		f.deployKeys = map[string][]string{}
+	}
+	f.deployKeys[repo] = append(f.deployKeys[repo], publicKey)
+	return nil
+}
+
+// AddWebhook records the webhook url for the repo
+func (f *FakeRepoProvider) AddWebhook(ctx context.Context, repo, hookURL string) error {
+	if f.Err != nil {
+		return f.Err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.webhooks == nil {
+		f.webhooks = map[string][]string{}
+	}
+	f.webhooks[repo] = append(f.webhooks[repo], hookURL)
+	return nil
+}
+
+// DeployKeys returns the public keys added to a repo
+func (f *FakeRepoProvider) DeployKeys(repo string) []string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.deployKeys[repo]
+}
+
+// Webhooks returns the webhook urls added to a repo
+func (f *FakeRepoProvider) Webhooks(repo string) []string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.webhooks[repo]
+}
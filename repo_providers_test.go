@@ -0,0 +1,212 @@
+package netlify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSite_ConnectRepo(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/deploy_keys", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"id":"my-key","public_key":"ssh-rsa AAAA"}`)
+	})
+
+	mux.HandleFunc("/api/v1/sites/my-site", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		params := map[string]*RepoOptions{}
+		json.NewDecoder(r.Body).Decode(&params)
+		if params["repo"].Provider != "gitlab" || params["repo"].DeployKeyID != "my-key" {
+			t.Errorf("Unexpected repo options: %v", params["repo"])
+		}
+
+		fmt.Fprint(w, `{"id":"my-site","deploy_hook":"https://api.netlify.com/hooks/github"}`)
+	})
+
+	site := &Site{Id: "my-site"}
+	site.setClient(client)
+
+	provider := &FakeRepoProvider{ProviderName: "gitlab"}
+	_, err := site.ConnectRepo(context.Background(), provider, &RepoOptions{Repo: "netlify/netlify-home", Branch: "master"})
+	if err != nil {
+		t.Errorf("Site.ConnectRepo returned an error: %v", err)
+	}
+
+	if keys := provider.DeployKeys("netlify/netlify-home"); len(keys) != 1 || keys[0] != "ssh-rsa AAAA" {
+		t.Errorf("Unexpected deploy keys: %v", keys)
+	}
+	if hooks := provider.Webhooks("netlify/netlify-home"); len(hooks) != 1 || hooks[0] != "https://api.netlify.com/hooks/github" {
+		t.Errorf("Unexpected webhooks: %v", hooks)
+	}
+}
+
+func TestSite_ConnectRepo_ProviderError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/sites/my-site", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Site shouldn't be updated when the deploy key can't be added")
+	})
+
+	site := &Site{Id: "my-site"}
+	site.setClient(client)
+
+	provider := &FakeRepoProvider{Err: errors.New("Not Found")}
+	_, err := site.ConnectRepo(context.Background(), provider, &RepoOptions{Repo: "netlify/netlify-home", DeployKey: &DeployKey{Id: "my-key"}})
+	if err != provider.Err {
+		t.Errorf("Expected the provider error, got %v", err)
+	}
+}
+
+func TestSite_ConnectRepo_DeployKeyID(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/deploy_keys/my-key", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":"my-key","public_key":"ssh-ed25519 AAAA"}`)
+	})
+
+	mux.HandleFunc("/api/v1/sites/my-site", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		fmt.Fprint(w, `{"id":"my-site","deploy_hook":"https://api.netlify.com/hooks/github"}`)
+	})
+
+	site := &Site{Id: "my-site"}
+	site.setClient(client)
+
+	provider := &FakeRepoProvider{}
+	repoOptions := &RepoOptions{Repo: "netlify/netlify-home", DeployKeyID: "my-key"}
+	if _, err := site.ConnectRepo(context.Background(), provider, repoOptions); err != nil {
+		t.Errorf("Site.ConnectRepo returned an error: %v", err)
+	}
+
+	if keys := provider.DeployKeys("netlify/netlify-home"); len(keys) != 1 || keys[0] != "ssh-ed25519 AAAA" {
+		t.Errorf("Expected the public key of the deploy key to be added, got %v", keys)
+	}
+	if repoOptions.Provider != "" || repoOptions.DeployKey != nil {
+		t.Errorf("Expected the repo options not to be changed, got %v", repoOptions)
+	}
+}
+
+func TestSite_ConnectRepo_Cleanup(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/deploy_keys", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"id":"my-key","public_key":"ssh-rsa AAAA"}`)
+	})
+
+	deleted := false
+	mux.HandleFunc("/api/v1/deploy_keys/my-key", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		deleted = true
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("/api/v1/sites/my-site", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Unprocessable Entity", http.StatusUnprocessableEntity)
+	})
+
+	site := &Site{Id: "my-site"}
+	site.setClient(client)
+
+	_, err := site.ConnectRepo(context.Background(), &FakeRepoProvider{}, &RepoOptions{Repo: "netlify/netlify-home"})
+	if err == nil {
+		t.Errorf("Expected Site.ConnectRepo to fail")
+	}
+	if !deleted {
+		t.Errorf("Expected the new deploy key to be deleted")
+	}
+}
+
+func TestRepoProviders(t *testing.T) {
+	tests := []struct {
+		provider RepoProvider
+		keyPath  string
+		hookPath string
+		auth     func(*http.Request) bool
+	}{
+		{
+			provider: &GitHubProvider{Token: "token"},
+			keyPath:  "/repos/netlify/netlify-home/keys",
+			hookPath: "/repos/netlify/netlify-home/hooks",
+			auth:     func(r *http.Request) bool { return r.Header.Get("Authorization") == "token token" },
+		},
+		{
+			provider: &GitLabProvider{Token: "token"},
+			keyPath:  "/projects/netlify%2Fnetlify-home/deploy_keys",
+			hookPath: "/projects/netlify%2Fnetlify-home/hooks",
+			auth:     func(r *http.Request) bool { return r.Header.Get("Private-Token") == "token" },
+		},
+		{
+			provider: &BitbucketProvider{Username: "user", AppPassword: "password"},
+			keyPath:  "/repositories/netlify/netlify-home/deploy-keys",
+			hookPath: "/repositories/netlify/netlify-home/hooks",
+			auth: func(r *http.Request) bool {
+				username, password, ok := r.BasicAuth()
+				return ok && username == "user" && password == "password"
+			},
+		},
+	}
+
+	for _, test := range tests {
+		requests := map[string]map[string]interface{}{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "POST")
+			if !test.auth(r) {
+				t.Errorf("Unauthenticated request to %v", test.provider.Name())
+			}
+			body := map[string]interface{}{}
+			json.NewDecoder(r.Body).Decode(&body)
+			requests[r.URL.EscapedPath()] = body
+			w.WriteHeader(http.StatusCreated)
+		}))
+
+		switch provider := test.provider.(type) {
+		case *GitHubProvider:
+			provider.BaseUrl = server.URL
+		case *GitLabProvider:
+			provider.BaseUrl = server.URL
+		case *BitbucketProvider:
+			provider.BaseUrl = server.URL
+		}
+
+		ctx := context.Background()
+		if err := test.provider.AddDeployKey(ctx, "netlify/netlify-home", "Netlify", "ssh-rsa AAAA"); err != nil {
+			t.Errorf("%v AddDeployKey returned an error: %v", test.provider.Name(), err)
+		}
+		if err := test.provider.AddWebhook(ctx, "netlify/netlify-home", "https://api.netlify.com/hooks/github"); err != nil {
+			t.Errorf("%v AddWebhook returned an error: %v", test.provider.Name(), err)
+		}
+		server.Close()
+
+		if requests[test.keyPath]["key"] != "ssh-rsa AAAA" {
+			t.Errorf("%v: unexpected deploy key request %v", test.provider.Name(), requests)
+		}
+		if _, ok := requests[test.hookPath]; !ok {
+			t.Errorf("%v: expected a webhook request to %v, got %v", test.provider.Name(), test.hookPath, requests)
+		}
+	}
+}
+
+func TestRepoProviders_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	provider := &GitHubProvider{Token: "token", BaseUrl: server.URL}
+	if err := provider.AddDeployKey(context.Background(), "netlify/missing", "Netlify", "ssh-rsa AAAA"); err == nil {
+		t.Errorf("Expected an error for a failed request")
+	}
+}
@@ -1,8 +1,10 @@
 package netlify
 
 import (
+	"encoding/json"
 	"errors"
 	"path"
+	"reflect"
 	"time"
 )
 
@@ -17,8 +19,9 @@ type SitesService struct {
 
 // Site represents a netlify Site
 type Site struct {
-	Id     string `json:"id"`
-	UserId string `json:"user_id"`
+	Id          string `json:"id"`
+	UserId      string `json:"user_id"`
+	AccountSlug string `json:"account_slug"`
 
 	// These fields can be updated through the API
 	Name              string   `json:"name"`
@@ -52,6 +55,28 @@ type Site struct {
 	// Access deploys for this site
 	Deploys *DeploysService
 
+	// Access continuous deployment builds for this site
+	Builds *BuildsService
+
+	// Access build hooks for this site
+	BuildHooks *BuildHooksService
+
+	// Access notification hooks for this site
+	Hooks *HooksService
+
+	// Access environment variables for this site
+	EnvVars *EnvVarsService
+
+	// Access deploy keys for continuous deployment of this site
+	DeployKeys *DeployKeysService
+
+	// Access branch split tests for this site
+	SplitTests *SplitTestsService
+
+	// Updatable fields as last returned by the API, used by Update to only
+	// send the fields that changed
+	loaded *Site
+
 	client *Client
 }
 
@@ -63,19 +88,28 @@ type DeployInfo struct {
 }
 
 // Settings for continuous deployment
+//
+// Site.Update only sends the settings that changed. The repo settings can't
+// be changed this way, use Site.ContinuousDeployment instead.
 type BuildSettings struct {
 	RepoType   string            `json:"repo_type"`
 	RepoURL    string            `json:"repo_url"`
 	RepoBranch string            `json:"repo_branch"`
 	Cmd        string            `json:"cmd"`
 	Dir        string            `json:"dir"`
+	Base       string            `json:"base"`
 	Env        map[string]string `json:"env"`
 
+	// Directory with the functions to deploy along with the site
+	FunctionsDir string `json:"functions_dir"`
+
 	CreatedAt Timestamp `json:"created_at"`
 	UpdatedAt Timestamp `json:"updated_at"`
 }
 
 // Settings for post processing
+//
+// Site.Update only sends the options that changed.
 type ProcessingSettings struct {
 	CSS struct {
 		Minify bool `json:"minify"`
@@ -103,9 +137,67 @@ type SiteAttributes struct {
 
 	ForceSSL bool `json:"force_ssl"`
 
-	ProcessingSettings bool `json:"processing_options"`
+	BuildSettings      *BuildSettings      `json:"build_settings,omitempty"`
+	ProcessingSettings *ProcessingSettings `json:"processing_settings,omitempty"`
+
+	Repo *RepoOptions `json:"repo,omitempty"`
+}
 
-	Repo *RepoOptions `json:"repo"`
+// Attributes for site.Patch. Only fields that are set will be sent, so
+// updating a site with a SiteUpdate won't revert concurrent changes to
+// other fields. Use netlify.String and netlify.Bool to set fields:
+//
+//	site.Patch(&netlify.SiteUpdate{
+//	  DomainAliases: &[]string{"www.example.com"},
+//	  ForceSSL: netlify.Bool(true),
+//	})
+type SiteUpdate struct {
+	Name              *string   `json:"name,omitempty"`
+	CustomDomain      *string   `json:"custom_domain,omitempty"`
+	DomainAliases     *[]string `json:"domain_aliases,omitempty"`
+	Password          *string   `json:"password,omitempty"`
+	NotificationEmail *string   `json:"notification_email,omitempty"`
+
+	SSL      *bool `json:"ssl,omitempty"`
+	ForceSSL *bool `json:"force_ssl,omitempty"`
+
+	BuildSettings      *BuildSettingsUpdate      `json:"build_settings,omitempty"`
+	ProcessingSettings *ProcessingSettingsUpdate `json:"processing_settings,omitempty"`
+}
+
+// Build settings for site.Patch, only fields that are set will be sent.
+// Env replaces all build environment variables when it's set.
+type BuildSettingsUpdate struct {
+	Cmd          *string            `json:"cmd,omitempty"`
+	Dir          *string            `json:"dir,omitempty"`
+	Base         *string            `json:"base,omitempty"`
+	FunctionsDir *string            `json:"functions_dir,omitempty"`
+	Env          *map[string]string `json:"env,omitempty"`
+}
+
+// Processing settings for site.Patch, only options that are set will be sent
+type ProcessingSettingsUpdate struct {
+	CSS    *AssetProcessingUpdate `json:"css,omitempty"`
+	JS     *AssetProcessingUpdate `json:"js,omitempty"`
+	HTML   *HTMLProcessingUpdate  `json:"html,omitempty"`
+	Images *ImageProcessingUpdate `json:"images,omitempty"`
+	Skip   *bool                  `json:"skip,omitempty"`
+}
+
+// CSS or JS processing options for site.Patch
+type AssetProcessingUpdate struct {
+	Minify *bool `json:"minify,omitempty"`
+	Bundle *bool `json:"bundle,omitempty"`
+}
+
+// HTML processing options for site.Patch
+type HTMLProcessingUpdate struct {
+	PrettyURLs *bool `json:"pretty_urls,omitempty"`
+}
+
+// Image processing options for site.Patch
+type ImageProcessingUpdate struct {
+	Optimize *bool `json:"optimize,omitempty"`
 }
 
 // Attributes for site.ProvisionCert
@@ -141,13 +233,16 @@ type RepoOptions struct {
 
 	// ID of a netlify deploy key used to access the repo
 	DeployKeyID string `json:"deploy_key_id"`
+
+	// Deploy key used to access the repo, sets DeployKeyID
+	DeployKey *DeployKey `json:"-"`
 }
 
 // Get a single Site from the API. The id can be either a site Id or the domain
 // of a site (ie. site.Get("mysite.netlify.com"))
 func (s *SitesService) Get(id string) (*Site, *Response, error) {
-	site := &Site{Id: id, client: s.client}
-	site.Deploys = &DeploysService{client: s.client, site: site}
+	site := &Site{Id: id}
+	site.setClient(s.client)
 	resp, err := site.Reload()
 
 	return site, resp, err
@@ -155,8 +250,8 @@ func (s *SitesService) Get(id string) (*Site, *Response, error) {
 
 // Create a new empty site.
 func (s *SitesService) Create(attributes *SiteAttributes) (*Site, *Response, error) {
-	site := &Site{client: s.client}
-	site.Deploys = &DeploysService{client: s.client, site: site}
+	site := &Site{}
+	site.setClient(s.client)
 
 	reqOptions := &RequestOptions{JsonBody: attributes}
 
@@ -173,14 +268,25 @@ func (s *SitesService) List(options *ListOptions) ([]Site, *Response, error) {
 
 	resp, err := s.client.Request("GET", "/sites", reqOptions, sites)
 
-	for _, site := range *sites {
-		site.client = s.client
-		site.Deploys = &DeploysService{client: s.client, site: &site}
+	for i := range *sites {
+		(*sites)[i].setClient(s.client)
 	}
 
 	return *sites, resp, err
 }
 
+// setClient sets the client of a site and the services scoped to it
+func (site *Site) setClient(client *Client) {
+	site.client = client
+	site.Deploys = &DeploysService{client: client, site: site}
+	site.Builds = &BuildsService{client: client, site: site}
+	site.BuildHooks = &BuildHooksService{client: client, site: site}
+	site.Hooks = &HooksService{client: client, site: site}
+	site.EnvVars = &EnvVarsService{client: client, site: site}
+	site.DeployKeys = &DeployKeysService{client: client, site: site}
+	site.SplitTests = &SplitTestsService{client: client, site: site}
+}
+
 func (site *Site) apiPath() string {
 	return path.Join("/sites", site.Id)
 }
@@ -192,23 +298,58 @@ func (site *Site) Reload() (*Response, error) {
 	return site.client.Request("GET", site.apiPath(), nil, site)
 }
 
-// Update will update the fields that can be updated through the API
+// Update will update the fields that can be updated through the API.
+// Only fields that changed since the site was last loaded from the API are
+// sent, so concurrent changes to other fields aren't reverted. For a site
+// that was never loaded, only fields that are set are sent. Build and
+// processing settings are compared option by option, only the build
+// environment is sent as a whole when any variable in it changed.
 func (site *Site) Update() (*Response, error) {
 	options := &RequestOptions{JsonBody: site.mutableParams()}
 
-	return site.client.Request("PUT", site.apiPath(), options, site)
+	return site.client.Request("PATCH", site.apiPath(), options, site)
 }
 
-// Configure Continuous Deployment for a site
+// Patch updates only the fields that are set in the SiteUpdate and reloads
+// the site from the response
+func (site *Site) Patch(update *SiteUpdate) (*Response, error) {
+	options := &RequestOptions{JsonBody: update}
+
+	return site.client.Request("PATCH", site.apiPath(), options, site)
+}
+
+// Configure Continuous Deployment for a site. The repoOptions aren't changed.
 func (site *Site) ContinuousDeployment(repoOptions *RepoOptions) (*Response, error) {
-	options := &RequestOptions{JsonBody: map[string]*RepoOptions{"repo": repoOptions}}
+	if repoOptions == nil {
+		return nil, errors.New("Cannot configure continuous deployment without repo options")
+	}
+
+	repo := *repoOptions
+	if repo.DeployKey != nil {
+		repo.DeployKeyID = repo.DeployKey.Id
+	}
+
+	options := &RequestOptions{JsonBody: map[string]*RepoOptions{"repo": &repo}}
 
 	return site.client.Request("PUT", site.apiPath(), options, site)
 }
 
 // Provision SSL Certificate for a site. Takes optional CertOptions to set a custom cert/chain/key.
 // Without this netlify will generate the certificate automatically.
+//
+// Custom certificates are checked with ValidateCert before they're uploaded,
+// against the domains of the site as they're currently set on netlify.
 func (site *Site) ProvisionCert(certOptions *CertOptions) (*Response, error) {
+	if certOptions != nil && (certOptions.Certificate != "" || certOptions.Key != "") {
+		current := &Site{Id: site.Id, client: site.client}
+		if resp, err := current.Reload(); err != nil {
+			return resp, err
+		}
+		if err := current.ValidateCert(certOptions); err != nil {
+			return nil, err
+		}
+	}
+
 	options := &RequestOptions{JsonBody: certOptions}
 
 	return site.client.Request("POST", path.Join(site.apiPath(), "ssl"), options, nil)
@@ -223,12 +364,149 @@ func (site *Site) Destroy() (*Response, error) {
 	return resp, err
 }
 
-func (site *Site) mutableParams() *SiteAttributes {
-	return &SiteAttributes{
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It keeps the updatable fields returned by the API so Update can tell which
+// fields changed.
+func (site *Site) UnmarshalJSON(data []byte) error {
+	type siteJSON Site
+	if err := json.Unmarshal(data, (*siteJSON)(site)); err != nil {
+		return err
+	}
+	site.loaded = site.updatableFields()
+	return nil
+}
+
+// updatableFields returns a copy of all the fields Update can change
+func (site *Site) updatableFields() *Site {
+	fields := &Site{
 		Name:              site.Name,
 		CustomDomain:      site.CustomDomain,
 		Password:          site.Password,
 		NotificationEmail: site.NotificationEmail,
+		DomainAliases:     append([]string{}, site.DomainAliases...),
 		ForceSSL:          site.ForceSSL,
 	}
+
+	if site.BuildSettings != nil {
+		buildSettings := *site.BuildSettings
+		buildSettings.Env = map[string]string{}
+		for key, value := range site.BuildSettings.Env {
+			buildSettings.Env[key] = value
+		}
+		fields.BuildSettings = &buildSettings
+	}
+	if site.ProcessingSettings != nil {
+		processingSettings := *site.ProcessingSettings
+		fields.ProcessingSettings = &processingSettings
+	}
+
+	return fields
+}
+
+func (site *Site) mutableParams() *SiteUpdate {
+	loaded := site.loaded
+	if loaded == nil {
+		loaded = (&Site{}).updatableFields()
+	}
+	current := site.updatableFields()
+
+	params := &SiteUpdate{}
+	if current.Name != loaded.Name {
+		params.Name = String(current.Name)
+	}
+	if current.CustomDomain != loaded.CustomDomain {
+		params.CustomDomain = String(current.CustomDomain)
+	}
+	if current.Password != loaded.Password {
+		params.Password = String(current.Password)
+	}
+	if current.NotificationEmail != loaded.NotificationEmail {
+		params.NotificationEmail = String(current.NotificationEmail)
+	}
+	if current.ForceSSL != loaded.ForceSSL {
+		params.ForceSSL = Bool(current.ForceSSL)
+	}
+	if !reflect.DeepEqual(current.DomainAliases, loaded.DomainAliases) {
+		params.DomainAliases = &current.DomainAliases
+	}
+	if current.BuildSettings != nil {
+		params.BuildSettings = current.BuildSettings.changes(loaded.BuildSettings)
+	}
+	if current.ProcessingSettings != nil {
+		params.ProcessingSettings = current.ProcessingSettings.changes(loaded.ProcessingSettings)
+	}
+
+	return params
+}
+
+// changes returns the settings that differ from old, or nil without changes
+func (b *BuildSettings) changes(old *BuildSettings) *BuildSettingsUpdate {
+	if old == nil {
+		old = &BuildSettings{Env: map[string]string{}}
+	}
+
+	update := &BuildSettingsUpdate{}
+	changed := false
+	for _, field := range []struct {
+		value, old string
+		update     **string
+	}{
+		{b.Cmd, old.Cmd, &update.Cmd},
+		{b.Dir, old.Dir, &update.Dir},
+		{b.Base, old.Base, &update.Base},
+		{b.FunctionsDir, old.FunctionsDir, &update.FunctionsDir},
+	} {
+		if field.value != field.old {
+			*field.update = String(field.value)
+			changed = true
+		}
+	}
+	if !reflect.DeepEqual(b.Env, old.Env) {
+		env := b.Env
+		update.Env = &env
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+	return update
+}
+
+// changes returns the options that differ from old, or nil without changes
+func (p *ProcessingSettings) changes(old *ProcessingSettings) *ProcessingSettingsUpdate {
+	if old == nil {
+		old = &ProcessingSettings{}
+	}
+
+	changedBool := func(value, old bool) *bool {
+		if value == old {
+			return nil
+		}
+		return Bool(value)
+	}
+	assetChanges := func(minify, oldMinify, bundle, oldBundle bool) *AssetProcessingUpdate {
+		update := &AssetProcessingUpdate{Minify: changedBool(minify, oldMinify), Bundle: changedBool(bundle, oldBundle)}
+		if update.Minify == nil && update.Bundle == nil {
+			return nil
+		}
+		return update
+	}
+
+	update := &ProcessingSettingsUpdate{
+		CSS:  assetChanges(p.CSS.Minify, old.CSS.Minify, p.CSS.Bundle, old.CSS.Bundle),
+		JS:   assetChanges(p.JS.Minify, old.JS.Minify, p.JS.Bundle, old.JS.Bundle),
+		Skip: changedBool(p.Skip, old.Skip),
+	}
+	if prettyURLs := changedBool(p.HTML.PrettyURLs, old.HTML.PrettyURLs); prettyURLs != nil {
+		update.HTML = &HTMLProcessingUpdate{PrettyURLs: prettyURLs}
+	}
+	if optimize := changedBool(p.Images.Optimize, old.Images.Optimize); optimize != nil {
+		update.Images = &ImageProcessingUpdate{Optimize: optimize}
+	}
+
+	if *update == (ProcessingSettingsUpdate{}) {
+		return nil
+	}
+	return update
 }
@@ -1,9 +1,11 @@
 package netlify
 
 import (
+	"bytes"
 	"fmt"
 	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -22,11 +24,43 @@ func TestSitesService_List(t *testing.T) {
 	}
 
 	expected := []Site{{Id: "first"}, {Id: "second"}}
+	for i := range expected {
+		expected[i].setClient(client)
+		expected[i].loaded = expected[i].updatableFields()
+	}
 	if !reflect.DeepEqual(sites, expected) {
 		t.Errorf("Expected Sites.List to return %v, returned %v", expected, sites)
 	}
 }
 
+func TestSitesService_List_Services(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/sites", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"id":"first"},{"id":"second"}]`)
+	})
+
+	mux.HandleFunc("/api/v1/sites/second/builds", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"id":"my-build"}]`)
+	})
+
+	sites, _, err := client.Sites.List(&ListOptions{})
+	if err != nil {
+		t.Fatalf("Sites.List returned an error: %v", err)
+	}
+
+	builds, _, err := sites[1].Builds.List(nil)
+	if err != nil {
+		t.Errorf("Builds.List returned an error: %v", err)
+	}
+	if len(builds) != 1 || builds[0].Id != "my-build" {
+		t.Errorf("Unexpected builds for a listed site: %v", builds)
+	}
+}
+
 func TestSitesService_List_With_Pagination(t *testing.T) {
 	setup()
 	defer teardown()
@@ -43,6 +77,10 @@ func TestSitesService_List_With_Pagination(t *testing.T) {
 	}
 
 	expected := []Site{{Id: "first"}, {Id: "second"}}
+	for i := range expected {
+		expected[i].setClient(client)
+		expected[i].loaded = expected[i].updatableFields()
+	}
 	if !reflect.DeepEqual(sites, expected) {
 		t.Errorf("Expected Sites.List to return %v, returned %v", expected, sites)
 	}
@@ -66,3 +104,103 @@ func TestSitesService_Get(t *testing.T) {
 		t.Errorf("Expected Sites.Get to return my-site, returned %v", site.Id)
 	}
 }
+
+func TestSite_Update_Build_Settings(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/sites/my-site", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+
+		buf := new(bytes.Buffer)
+		buf.ReadFrom(r.Body)
+
+		expected := `{"build_settings":{"cmd":"npm run build","dir":"dist","functions_dir":"functions"}}`
+		if expected != strings.TrimSpace(buf.String()) {
+			t.Errorf("Expected JSON: %v\nGot JSON: %v", expected, buf.String())
+		}
+
+		fmt.Fprint(w, `{"id":"my-site","build_settings":{"cmd":"npm run build","dir":"dist","functions_dir":"functions"}}`)
+	})
+
+	site := &Site{Id: "my-site"}
+	site.setClient(client)
+	site.BuildSettings = &BuildSettings{Cmd: "npm run build", Dir: "dist", FunctionsDir: "functions"}
+
+	if _, err := site.Update(); err != nil {
+		t.Errorf("Site.Update returned an error: %v", err)
+	}
+}
+
+func TestSite_Update_Changed_Fields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/sites/my-site", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"id":"my-site","name":"my-site","custom_domain":"www.example.com",
+				"build_settings":{"repo_url":"https://github.com/netlify/netlify-home","repo_branch":"master","cmd":"make","base":"site","env":{"API_URL":"https://api.example.com"}},
+				"processing_settings":{"css":{"minify":true,"bundle":true},"images":{"optimize":true}}}`)
+		case "PATCH":
+			buf := new(bytes.Buffer)
+			buf.ReadFrom(r.Body)
+
+			expected := `{"notification_email":"team@example.com",` +
+				`"build_settings":{"base":"","env":{}},` +
+				`"processing_settings":{"css":{"bundle":false}}}`
+			if expected != strings.TrimSpace(buf.String()) {
+				t.Errorf("Expected JSON: %v\nGot JSON: %v", expected, buf.String())
+			}
+
+			fmt.Fprint(w, `{"id":"my-site"}`)
+		default:
+			t.Errorf("Unexpected request method %v", r.Method)
+		}
+	})
+
+	site, _, err := client.Sites.Get("my-site")
+	if err != nil {
+		t.Fatalf("Sites.Get returned an error: %v", err)
+	}
+
+	site.NotificationEmail = "team@example.com"
+	site.BuildSettings.Base = ""
+	site.BuildSettings.Env = nil
+	site.ProcessingSettings.CSS.Bundle = false
+
+	if _, err := site.Update(); err != nil {
+		t.Errorf("Site.Update returned an error: %v", err)
+	}
+}
+
+func TestSite_Patch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/sites/my-site", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+
+		buf := new(bytes.Buffer)
+		buf.ReadFrom(r.Body)
+
+		expected := `{"domain_aliases":["www.example.com"],"force_ssl":false}`
+		if expected != strings.TrimSpace(buf.String()) {
+			t.Errorf("Expected JSON: %v\nGot JSON: %v", expected, buf.String())
+		}
+
+		fmt.Fprint(w, `{"id":"my-site","name":"concurrently-renamed","domain_aliases":["www.example.com"]}`)
+	})
+
+	site := &Site{Id: "my-site", Name: "my-site"}
+	site.setClient(client)
+
+	_, err := site.Patch(&SiteUpdate{DomainAliases: &[]string{"www.example.com"}, ForceSSL: Bool(false)})
+	if err != nil {
+		t.Errorf("Site.Patch returned an error: %v", err)
+	}
+
+	if site.Name != "concurrently-renamed" || !reflect.DeepEqual(site.DomainAliases, []string{"www.example.com"}) {
+		t.Errorf("Expected site to be reloaded from the response, got %v", site)
+	}
+}
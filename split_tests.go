@@ -0,0 +1,167 @@
+package netlify
+
+import (
+	"errors"
+	"fmt"
+	"path"
+)
+
+// SplitTest splits the traffic of a site between the deploys of several branches
+type SplitTest struct {
+	Id     string `json:"id"`
+	SiteId string `json:"site_id"`
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+
+	Branches []SplitTestBranch `json:"branches"`
+
+	// Active is true while the split test is enabled
+	Active bool `json:"active"`
+
+	CreatedAt     Timestamp  `json:"created_at"`
+	UpdatedAt     Timestamp  `json:"updated_at"`
+	UnpublishedAt *Timestamp `json:"unpublished_at"`
+
+	client *Client
+}
+
+// SplitTestBranch is a branch in a split test and the percentage of the
+// traffic it gets
+type SplitTestBranch struct {
+	Branch     string `json:"branch"`
+	Percentage int    `json:"percentage"`
+}
+
+// SplitTestWeights maps branch names to the percentage of the traffic they
+// get. The weights must add up to 100.
+type SplitTestWeights map[string]int
+
+// SplitTestsService is used to access all SplitTest related API methods
+type SplitTestsService struct {
+	site   *Site
+	client *Client
+}
+
+type splitTestSetup struct {
+	BranchTests SplitTestWeights `json:"branch_tests"`
+}
+
+// Validate checks that there are at least two branches, that no weight is
+// negative and that the weights add up to 100
+func (weights SplitTestWeights) Validate() error {
+	if len(weights) < 2 {
+		return errors.New("A split test needs at least two branches")
+	}
+
+	total := 0
+	for branch, weight := range weights {
+		if branch == "" {
+			return errors.New("Split test branch names can't be empty")
+		}
+		if weight < 0 {
+			return fmt.Errorf("Split test weight for %v can't be negative: %d", branch, weight)
+		}
+		total += weight
+	}
+	if total != 100 {
+		return fmt.Errorf("Split test weights must add up to 100, got %d", total)
+	}
+	return nil
+}
+
+func (s *SplitTestsService) apiPath() string {
+	return path.Join(s.site.apiPath(), "traffic_splits")
+}
+
+// Create a new split test between the branches in weights. The weights are
+// validated before the split test is created. New split tests are disabled
+// until Enable is called.
+func (s *SplitTestsService) Create(weights SplitTestWeights) (*SplitTest, *Response, error) {
+	if err := weights.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	splitTest := &SplitTest{SiteId: s.site.Id, client: s.client}
+
+	reqOptions := &RequestOptions{JsonBody: &splitTestSetup{BranchTests: weights}}
+
+	resp, err := s.client.Request("POST", s.apiPath(), reqOptions, splitTest)
+
+	return splitTest, resp, err
+}
+
+// List all split tests for the site
+func (s *SplitTestsService) List() ([]SplitTest, *Response, error) {
+	splitTests := new([]SplitTest)
+
+	resp, err := s.client.Request("GET", s.apiPath(), nil, splitTests)
+
+	for i := range *splitTests {
+		(*splitTests)[i].SiteId = s.site.Id
+		(*splitTests)[i].client = s.client
+	}
+
+	return *splitTests, resp, err
+}
+
+// Get a specific split test
+func (s *SplitTestsService) Get(id string) (*SplitTest, *Response, error) {
+	splitTest := &SplitTest{Id: id, SiteId: s.site.Id, client: s.client}
+	resp, err := splitTest.Reload()
+
+	return splitTest, resp, err
+}
+
+func (splitTest *SplitTest) apiPath() string {
+	return path.Join("/sites", splitTest.SiteId, "traffic_splits", splitTest.Id)
+}
+
+// Weights returns the percentage of the traffic each branch gets
+func (splitTest *SplitTest) Weights() SplitTestWeights {
+	weights := SplitTestWeights{}
+	for _, branch := range splitTest.Branches {
+		weights[branch.Branch] = branch.Percentage
+	}
+	return weights
+}
+
+// Reload a split test from the API
+func (splitTest *SplitTest) Reload() (*Response, error) {
+	if splitTest.Id == "" {
+		return nil, errors.New("Cannot fetch split test without an ID")
+	}
+	return splitTest.client.Request("GET", splitTest.apiPath(), nil, splitTest)
+}
+
+// Update the branches and weights of the split test. The weights are
+// validated before the split test is updated.
+func (splitTest *SplitTest) Update(weights SplitTestWeights) (*Response, error) {
+	if err := weights.Validate(); err != nil {
+		return nil, err
+	}
+
+	options := &RequestOptions{JsonBody: &splitTestSetup{BranchTests: weights}}
+
+	return splitTest.client.Request("PUT", splitTest.apiPath(), options, splitTest)
+}
+
+// Enable starts splitting the traffic of the site between the branches
+func (splitTest *SplitTest) Enable() (*Response, error) {
+	return splitTest.setActive("publish", true)
+}
+
+// Disable stops the split test, all traffic goes to the production deploy again
+func (splitTest *SplitTest) Disable() (*Response, error) {
+	return splitTest.setActive("unpublish", false)
+}
+
+func (splitTest *SplitTest) setActive(action string, active bool) (*Response, error) {
+	resp, err := splitTest.client.Request("POST", path.Join(splitTest.apiPath(), action), nil, nil)
+	if resp != nil && resp.Body != nil {
+		resp.Body.Close()
+	}
+	if err == nil {
+		splitTest.Active = active
+	}
+	return resp, err
+}
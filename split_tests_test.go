@@ -0,0 +1,155 @@
+package netlify
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestSplitTestWeights_Validate(t *testing.T) {
+	cases := []struct {
+		weights SplitTestWeights
+		valid   bool
+	}{
+		{SplitTestWeights{"master": 50, "feature": 50}, true},
+		{SplitTestWeights{"master": 80, "feature": 10, "redesign": 10}, true},
+		{SplitTestWeights{"master": 100, "feature": 0}, true},
+		{SplitTestWeights{"master": 100}, false},
+		{SplitTestWeights{"master": 50, "feature": 40}, false},
+		{SplitTestWeights{"master": 120, "feature": -20}, false},
+		{SplitTestWeights{"master": 50, "": 50}, false},
+	}
+
+	for _, c := range cases {
+		err := c.weights.Validate()
+		if c.valid && err != nil {
+			t.Errorf("Expected %v to be valid, got %v", c.weights, err)
+		}
+		if !c.valid && err == nil {
+			t.Errorf("Expected %v to be invalid", c.weights)
+		}
+	}
+}
+
+func TestSplitTestsService_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/sites/my-site/traffic_splits", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		setup := &splitTestSetup{}
+		json.NewDecoder(r.Body).Decode(setup)
+		if !reflect.DeepEqual(setup.BranchTests, SplitTestWeights{"master": 70, "feature": 30}) {
+			t.Errorf("Unexpected branch tests: %v", setup.BranchTests)
+		}
+
+		fmt.Fprint(w, `{"id":"my-test","branches":[{"branch":"master","percentage":70},{"branch":"feature","percentage":30}],"active":false,"unpublished_at":null}`)
+	})
+
+	site := &Site{Id: "my-site"}
+	site.setClient(client)
+
+	splitTest, _, err := site.SplitTests.Create(SplitTestWeights{"master": 70, "feature": 30})
+	if err != nil {
+		t.Fatalf("SplitTests.Create returned an error: %v", err)
+	}
+
+	if splitTest.Id != "my-test" || splitTest.Weights()["feature"] != 30 || splitTest.UnpublishedAt != nil {
+		t.Errorf("Unexpected split test: %v", splitTest)
+	}
+}
+
+func TestSplitTestsService_Create_Invalid(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/sites/my-site/traffic_splits", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Invalid split tests should not be sent to the API")
+	})
+
+	site := &Site{Id: "my-site"}
+	site.setClient(client)
+
+	if _, _, err := site.SplitTests.Create(SplitTestWeights{"master": 60, "feature": 30}); err == nil {
+		t.Errorf("Expected an error for weights that don't add up to 100")
+	}
+}
+
+func TestSplitTestsService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/sites/my-site/traffic_splits", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"id":"first","active":true},{"id":"second"}]`)
+	})
+
+	site := &Site{Id: "my-site"}
+	site.setClient(client)
+
+	splitTests, _, err := site.SplitTests.List()
+	if err != nil {
+		t.Errorf("SplitTests.List returned an error: %v", err)
+	}
+
+	if len(splitTests) != 2 || !splitTests[0].Active || splitTests[1].SiteId != "my-site" {
+		t.Errorf("Unexpected split tests: %v", splitTests)
+	}
+}
+
+func TestSplitTest_Update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/sites/my-site/traffic_splits/my-test", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		setup := &splitTestSetup{}
+		json.NewDecoder(r.Body).Decode(setup)
+		if setup.BranchTests["feature"] != 50 {
+			t.Errorf("Unexpected branch tests: %v", setup.BranchTests)
+		}
+
+		fmt.Fprint(w, `{"id":"my-test","branches":[{"branch":"master","percentage":50},{"branch":"feature","percentage":50}]}`)
+	})
+
+	splitTest := &SplitTest{Id: "my-test", SiteId: "my-site", client: client}
+	if _, err := splitTest.Update(SplitTestWeights{"master": 50, "feature": 50}); err != nil {
+		t.Errorf("SplitTest.Update returned an error: %v", err)
+	}
+
+	if splitTest.Weights()["master"] != 50 {
+		t.Errorf("Unexpected split test: %v", splitTest)
+	}
+}
+
+func TestSplitTest_Enable_Disable(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := []string{}
+	mux.HandleFunc("/api/v1/sites/my-site/traffic_splits/my-test/publish", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		calls = append(calls, "publish")
+	})
+	mux.HandleFunc("/api/v1/sites/my-site/traffic_splits/my-test/unpublish", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		calls = append(calls, "unpublish")
+	})
+
+	splitTest := &SplitTest{Id: "my-test", SiteId: "my-site", client: client}
+
+	if _, err := splitTest.Enable(); err != nil || !splitTest.Active {
+		t.Errorf("SplitTest.Enable failed: %v", err)
+	}
+	if _, err := splitTest.Disable(); err != nil || splitTest.Active {
+		t.Errorf("SplitTest.Disable failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(calls, []string{"publish", "unpublish"}) {
+		t.Errorf("Unexpected calls: %v", calls)
+	}
+}
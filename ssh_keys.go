@@ -0,0 +1,122 @@
+package netlify
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// Types of key pairs GenerateKeyPair can create
+const (
+	KeyTypeEd25519 = "ed25519"
+	KeyTypeRSA     = "rsa"
+)
+
+const rsaKeyBits = 4096
+
+// KeyPair is an SSH key pair generated locally
+type KeyPair struct {
+	// Public key in the authorized_keys format, ie. "ssh-ed25519 AAAA..."
+	PublicKey string `json:"public_key"`
+
+	// PEM encoded private key in the OpenSSH format
+	PrivateKey string `json:"private_key"`
+}
+
+// GenerateKeyPair generates a new SSH key pair of the given type
+func GenerateKeyPair(keyType string) (*KeyPair, error) {
+	var private crypto.Signer
+
+	switch keyType {
+	case KeyTypeEd25519:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		private = key
+	case KeyTypeRSA:
+		key, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+		if err != nil {
+			return nil, err
+		}
+		private = key
+	default:
+		return nil, fmt.Errorf("Unsupported key type %s", keyType)
+	}
+
+	publicKey, err := ssh.NewPublicKey(private.Public())
+	if err != nil {
+		return nil, err
+	}
+	block, err := ssh.MarshalPrivateKey(private, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeyPair{PublicKey: authorizedKey(publicKey), PrivateKey: string(pem.EncodeToMemory(block))}, nil
+}
+
+// CreateFromKeyPair registers the public key of a locally generated key pair
+// as a deploy key. The private key is never sent to netlify. Add the public
+// key to the repository as usual.
+func (d *DeployKeysService) CreateFromKeyPair(keyPair *KeyPair) (*DeployKey, *Response, error) {
+	deployKey := &DeployKey{client: d.client}
+
+	reqOptions := &RequestOptions{JsonBody: map[string]string{"public_key": keyPair.PublicKey}}
+
+	resp, err := d.client.Request("POST", "/deploy_keys", reqOptions, deployKey)
+
+	return deployKey, resp, err
+}
+
+// Fingerprint returns the SHA256 fingerprint of the public key, in the
+// same format as ssh-keygen -l
+func (deployKey *DeployKey) Fingerprint() (string, error) {
+	return KeyFingerprint(deployKey.PublicKey)
+}
+
+// MatchesPrivateKey checks if a PEM encoded private key is the other half
+// of the public key of the deploy key
+func (deployKey *DeployKey) MatchesPrivateKey(privateKey string) (bool, error) {
+	expected, err := deployKey.Fingerprint()
+	if err != nil {
+		return false, err
+	}
+
+	key, err := ssh.ParseRawPrivateKey([]byte(privateKey))
+	if err != nil {
+		return false, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return false, errors.New("Unsupported private key")
+	}
+	publicKey, err := ssh.NewPublicKey(signer.Public())
+	if err != nil {
+		return false, err
+	}
+
+	return expected == ssh.FingerprintSHA256(publicKey), nil
+}
+
+// KeyFingerprint returns the SHA256 fingerprint of a public key in the
+// authorized_keys format
+func KeyFingerprint(publicKey string) (string, error) {
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(publicKey))
+	if err != nil {
+		return "", fmt.Errorf("Invalid public key: %v", err)
+	}
+	return ssh.FingerprintSHA256(key), nil
+}
+
+// authorizedKey encodes a public key in the authorized_keys format
+func authorizedKey(key ssh.PublicKey) string {
+	return strings.TrimSuffix(string(ssh.MarshalAuthorizedKey(key)), "\n")
+}
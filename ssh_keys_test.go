@@ -0,0 +1,100 @@
+package netlify
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGenerateKeyPair(t *testing.T) {
+	for _, keyType := range []string{KeyTypeEd25519, KeyTypeRSA} {
+		keyPair, err := GenerateKeyPair(keyType)
+		if err != nil {
+			t.Fatalf("GenerateKeyPair(%v) returned an error: %v", keyType, err)
+		}
+
+		if !strings.HasPrefix(keyPair.PublicKey, "ssh-"+keyType+" ") {
+			t.Errorf("Unexpected public key for %v: %v", keyType, keyPair.PublicKey)
+		}
+
+		deployKey := &DeployKey{PublicKey: keyPair.PublicKey}
+		matches, err := deployKey.MatchesPrivateKey(keyPair.PrivateKey)
+		if err != nil {
+			t.Errorf("MatchesPrivateKey returned an error for %v: %v", keyType, err)
+		}
+		if !matches {
+			t.Errorf("Expected the %v private key to match its public key", keyType)
+		}
+
+		fingerprint, err := deployKey.Fingerprint()
+		if err != nil || !strings.HasPrefix(fingerprint, "SHA256:") {
+			t.Errorf("Unexpected fingerprint for %v: %v (%v)", keyType, fingerprint, err)
+		}
+	}
+}
+
+func TestGenerateKeyPair_UnknownType(t *testing.T) {
+	if _, err := GenerateKeyPair("dsa"); err == nil {
+		t.Errorf("Expected an error for an unsupported key type")
+	}
+}
+
+func TestDeployKey_MatchesPrivateKey_Mismatch(t *testing.T) {
+	first, _ := GenerateKeyPair(KeyTypeEd25519)
+	second, _ := GenerateKeyPair(KeyTypeEd25519)
+
+	matches, err := (&DeployKey{PublicKey: first.PublicKey}).MatchesPrivateKey(second.PrivateKey)
+	if err != nil {
+		t.Errorf("MatchesPrivateKey returned an error: %v", err)
+	}
+	if matches {
+		t.Errorf("Expected keys from different pairs not to match")
+	}
+}
+
+func TestKeyFingerprint(t *testing.T) {
+	fingerprint, err := KeyFingerprint("ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIFnw/p8FFSak0qUHLaw4cnVZZq74MMIP3AfDkxTHJptn deploy@netlify")
+	if err != nil {
+		t.Errorf("KeyFingerprint returned an error: %v", err)
+	}
+
+	expected, _ := KeyFingerprint("ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIFnw/p8FFSak0qUHLaw4cnVZZq74MMIP3AfDkxTHJptn")
+	if fingerprint != expected {
+		t.Errorf("Expected the comment to be ignored, got %v and %v", fingerprint, expected)
+	}
+
+	if _, err := KeyFingerprint("not a key"); err == nil {
+		t.Errorf("Expected an error for an invalid public key")
+	}
+}
+
+func TestDeployKeysService_CreateFromKeyPair(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/deploy_keys", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		params := map[string]string{}
+		json.NewDecoder(r.Body).Decode(&params)
+		if params["public_key"] != "ssh-ed25519 AAAA" {
+			t.Errorf("Unexpected public key: %v", params)
+		}
+		if _, ok := params["private_key"]; ok {
+			t.Errorf("The private key should never be sent")
+		}
+
+		fmt.Fprint(w, `{"id":"my-key","public_key":"ssh-ed25519 AAAA"}`)
+	})
+
+	deployKey, _, err := client.DeployKeys.CreateFromKeyPair(&KeyPair{PublicKey: "ssh-ed25519 AAAA", PrivateKey: "private"})
+	if err != nil {
+		t.Errorf("DeployKeys.CreateFromKeyPair returned an error: %v", err)
+	}
+
+	if deployKey.Id != "my-key" {
+		t.Errorf("Unexpected deploy key: %v", deployKey)
+	}
+}
@@ -0,0 +1,140 @@
+package netlify
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"path"
+	"time"
+)
+
+// States of an SSLCert
+const (
+	SSLStatePending = "pending"
+	SSLStateIssued  = "issued"
+	SSLStateCustom  = "custom"
+	SSLStateError   = "error"
+)
+
+// SSLCert is the certificate a site is served with
+type SSLCert struct {
+	State   string   `json:"state"`
+	Domains []string `json:"domains"`
+	Issuer  string   `json:"issuer"`
+
+	ExpiresAt Timestamp `json:"expires_at"`
+	CreatedAt Timestamp `json:"created_at"`
+	UpdatedAt Timestamp `json:"updated_at"`
+}
+
+// Ready is true when the certificate is in place and serving the site
+func (cert *SSLCert) Ready() bool {
+	return cert.State == SSLStateIssued || cert.State == SSLStateCustom
+}
+
+// NeedsRenewal is true when the certificate expires within the given duration
+func (cert *SSLCert) NeedsRenewal(within time.Duration) bool {
+	return !cert.ExpiresAt.IsZero() && cert.ExpiresAt.Before(time.Now().Add(within))
+}
+
+// SSLStatus fetches the state of the SSL certificate of a site
+func (site *Site) SSLStatus() (*SSLCert, *Response, error) {
+	cert := &SSLCert{}
+
+	resp, err := site.client.Request("GET", path.Join(site.apiPath(), "ssl"), nil, cert)
+
+	return cert, resp, err
+}
+
+// WaitForSSL waits for the certificate of a site to be provisioned
+func (site *Site) WaitForSSL(ctx context.Context) (*SSLCert, error) {
+	var cert *SSLCert
+	err := poll(ctx, func() (bool, error) {
+		var err error
+		if cert, _, err = site.SSLStatus(); err != nil {
+			return true, err
+		}
+		if cert.State == SSLStateError {
+			return true, errors.New("Error provisioning SSL certificate")
+		}
+		return cert.Ready(), nil
+	})
+
+	return cert, err
+}
+
+// ValidateCert checks a custom certificate bundle before it's uploaded with
+// ProvisionCert. The certificate must cover the custom domain and all
+// domain aliases of the site.
+func (site *Site) ValidateCert(certOptions *CertOptions) error {
+	domains := site.DomainAliases
+	if site.CustomDomain != "" {
+		domains = append([]string{site.CustomDomain}, domains...)
+	}
+	return certOptions.Validate(domains)
+}
+
+// Validate checks that the key matches the certificate, that the CA
+// certificates are ordered from the issuer of the certificate up to the
+// root, and that the certificate is valid for each of the domains.
+func (c *CertOptions) Validate(domains []string) error {
+	pair, err := tls.X509KeyPair([]byte(c.Certificate), []byte(c.Key))
+	if err != nil {
+		return fmt.Errorf("Invalid certificate or key: %v", err)
+	}
+
+	leaf, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return err
+	}
+
+	chain := []*x509.Certificate{leaf}
+	for _, caPEM := range c.CaCertificates {
+		certs, err := parseCertificates(caPEM)
+		if err != nil {
+			return err
+		}
+		chain = append(chain, certs...)
+	}
+	for i := 1; i < len(chain); i++ {
+		if err := chain[i-1].CheckSignatureFrom(chain[i]); err != nil {
+			return fmt.Errorf("CA certificate %d (%s) did not issue %s, check the order of the chain",
+				i, chain[i].Subject.CommonName, chain[i-1].Subject.CommonName)
+		}
+	}
+
+	for _, domain := range domains {
+		if err := leaf.VerifyHostname(domain); err != nil {
+			return fmt.Errorf("Certificate doesn't cover %s", domain)
+		}
+	}
+
+	return nil
+}
+
+func parseCertificates(data string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := []byte(data)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("No certificates found in CA certificate")
+	}
+	return certs, nil
+}
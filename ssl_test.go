@@ -0,0 +1,147 @@
+package netlify
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func testCert(t *testing.T, name string, dnsNames []string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		DNSNames:              dnsNames,
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  len(dnsNames) == 0,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	keyDer, _ := x509.MarshalECPrivateKey(key)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+	return cert, key, string(certPEM), string(keyPEM)
+}
+
+func TestCertOptions_Validate(t *testing.T) {
+	ca, caKey, caPEM, _ := testCert(t, "Test CA", nil, nil, nil)
+	intermediate, intermediateKey, intermediatePEM, _ := testCert(t, "Test Intermediate", nil, ca, caKey)
+	_, _, leafPEM, leafKeyPEM := testCert(t, "example.com", []string{"example.com", "*.example.com"}, intermediate, intermediateKey)
+	_, _, _, otherKeyPEM := testCert(t, "other.com", []string{"other.com"}, ca, caKey)
+
+	cases := []struct {
+		name    string
+		options CertOptions
+		domains []string
+		valid   bool
+	}{
+		{"valid", CertOptions{leafPEM, leafKeyPEM, []string{intermediatePEM, caPEM}}, []string{"example.com", "www.example.com"}, true},
+		{"wrong key", CertOptions{leafPEM, otherKeyPEM, []string{intermediatePEM, caPEM}}, []string{"example.com"}, false},
+		{"wrong chain order", CertOptions{leafPEM, leafKeyPEM, []string{caPEM, intermediatePEM}}, []string{"example.com"}, false},
+		{"uncovered domain", CertOptions{leafPEM, leafKeyPEM, []string{intermediatePEM}}, []string{"example.org"}, false},
+	}
+
+	for _, c := range cases {
+		err := c.options.Validate(c.domains)
+		if c.valid && err != nil {
+			t.Errorf("%s: expected certificate to be valid, got %v", c.name, err)
+		}
+		if !c.valid && err == nil {
+			t.Errorf("%s: expected certificate to be invalid", c.name)
+		}
+	}
+}
+
+func TestSite_SSLStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	expiresAt := time.Now().Add(7 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	mux.HandleFunc("/api/v1/sites/my-site/ssl", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprintf(w, `{"state":"issued","domains":["example.com"],"issuer":"Let's Encrypt","expires_at":"%s"}`, expiresAt)
+	})
+
+	site := &Site{Id: "my-site"}
+	site.setClient(client)
+
+	cert, _, err := site.SSLStatus()
+	if err != nil {
+		t.Fatalf("Site.SSLStatus returned an error: %v", err)
+	}
+
+	if !cert.Ready() {
+		t.Errorf("Expected certificate in state %v to be ready", cert.State)
+	}
+	if !cert.NeedsRenewal(30*24*time.Hour) || cert.NeedsRenewal(24*time.Hour) {
+		t.Errorf("Expected certificate expiring at %v to need renewal within 30 days only", cert.ExpiresAt)
+	}
+}
+
+func TestSite_ProvisionCert_Without_Certificate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	provisioned := false
+	mux.HandleFunc("/api/v1/sites/my-site/ssl", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		provisioned = true
+	})
+
+	site := &Site{Id: "my-site", CustomDomain: "example.com"}
+	site.setClient(client)
+
+	if _, err := site.ProvisionCert(&CertOptions{}); err != nil {
+		t.Errorf("Site.ProvisionCert returned an error: %v", err)
+	}
+	if !provisioned {
+		t.Errorf("Expected a certificate to be provisioned")
+	}
+}
+
+func TestSite_ProvisionCert_Current_Domains(t *testing.T) {
+	setup()
+	defer teardown()
+
+	ca, caKey, caPEM, _ := testCert(t, "Test CA", nil, nil, nil)
+	_, _, leafPEM, leafKeyPEM := testCert(t, "example.com", []string{"example.com"}, ca, caKey)
+
+	mux.HandleFunc("/api/v1/sites/my-site", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":"my-site","custom_domain":"example.com","domain_aliases":["www.example.com"]}`)
+	})
+	mux.HandleFunc("/api/v1/sites/my-site/ssl", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("A certificate that doesn't cover all domains should not be uploaded")
+	})
+
+	site := &Site{Id: "my-site", CustomDomain: "example.com"}
+	site.setClient(client)
+
+	_, err := site.ProvisionCert(&CertOptions{Certificate: leafPEM, Key: leafKeyPEM, CaCertificates: []string{caPEM}})
+	if err == nil {
+		t.Errorf("Expected Site.ProvisionCert to check the domain aliases set on netlify")
+	}
+}
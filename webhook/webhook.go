@@ -0,0 +1,209 @@
+/*
+Package webhook verifies and dispatches the deploy notifications netlify
+sends to URL hooks.
+
+Netlify signs every notification with a JWS in the X-Webhook-Signature
+header, using the secret configured for the hook:
+
+	dispatcher := webhook.NewDispatcher()
+	dispatcher.On(netlify.HookEventDeployFailed, func(event *webhook.Event) error {
+	  log.Printf("Deploy %s failed: %s", event.Deploy.Id, event.Deploy.ErrorMessage)
+	  return nil
+	})
+
+	http.Handle("/netlify", webhook.Middleware(secret, dispatcher))
+*/
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/netlify/netlify-go"
+)
+
+const (
+	// SignatureHeader holds the JWS netlify signs notifications with
+	SignatureHeader = "X-Webhook-Signature"
+
+	// EventHeader holds the name of the event that triggered the notification
+	EventHeader = "X-Netlify-Event"
+
+	signatureIssuer = "netlify"
+
+	// MaxBodySize is the largest notification body Middleware accepts
+	MaxBodySize = 1 << 20
+)
+
+type contextKey struct{}
+
+// Event is a verified deploy notification
+type Event struct {
+	// Name of the event, one of the netlify.HookEvent constants
+	Name string
+
+	Deploy *netlify.Deploy
+}
+
+type signatureHeader struct {
+	Alg string `json:"alg"`
+}
+
+type signatureClaims struct {
+	Issuer    string `json:"iss"`
+	Sha256    string `json:"sha256"`
+	ExpiresAt int64  `json:"exp"`
+	NotBefore int64  `json:"nbf"`
+}
+
+// Verify checks that signature is a valid HS256 JWS for body, signed with secret
+func Verify(secret, signature string, body []byte) error {
+	if signature == "" {
+		return errors.New("Missing webhook signature")
+	}
+
+	parts := strings.Split(signature, ".")
+	if len(parts) != 3 {
+		return errors.New("Malformed webhook signature")
+	}
+
+	header := &signatureHeader{}
+	if err := decodeSegment(parts[0], header); err != nil {
+		return err
+	}
+	if header.Alg != "HS256" {
+		return errors.New("Unexpected signing method: " + header.Alg)
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	expected, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(expected, mac.Sum(nil)) {
+		return errors.New("Invalid webhook signature")
+	}
+
+	claims := &signatureClaims{}
+	if err := decodeSegment(parts[1], claims); err != nil {
+		return err
+	}
+
+	now := time.Now().Unix()
+	if claims.ExpiresAt != 0 && now > claims.ExpiresAt {
+		return errors.New("Webhook signature has expired")
+	}
+	if claims.NotBefore != 0 && now < claims.NotBefore {
+		return errors.New("Webhook signature is not valid yet")
+	}
+
+	if claims.Issuer != signatureIssuer {
+		return errors.New("Invalid webhook signature issuer: " + claims.Issuer)
+	}
+
+	sum := sha256.Sum256(body)
+	if claims.Sha256 != hex.EncodeToString(sum[:]) {
+		return errors.New("Webhook signature doesn't match the request body")
+	}
+
+	return nil
+}
+
+// decodeSegment decodes a base64url encoded JSON segment of a JWS
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return errors.New("Malformed webhook signature")
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return errors.New("Malformed webhook signature")
+	}
+	return nil
+}
+
+// Middleware verifies the signature of incoming notifications and decodes
+// them before passing them on to next. Requests with a missing or invalid
+// signature are rejected with a 401, and bodies larger than MaxBodySize
+// with a 413.
+//
+// The decoded event can be retrieved with FromContext.
+func Middleware(secret string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, MaxBodySize))
+		r.Body.Close()
+		if err != nil {
+			if len(body) >= MaxBodySize {
+				http.Error(w, "Request body too large", http.StatusRequestEntityTooLarge)
+			} else {
+				http.Error(w, "Error reading request body", http.StatusBadRequest)
+			}
+			return
+		}
+
+		if err := Verify(secret, r.Header.Get(SignatureHeader), body); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		event := &Event{Name: r.Header.Get(EventHeader), Deploy: &netlify.Deploy{}}
+		if err := json.Unmarshal(body, event.Deploy); err != nil {
+			http.Error(w, "Error decoding deploy: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey{}, event)))
+	})
+}
+
+// FromContext returns the event decoded by Middleware
+func FromContext(ctx context.Context) (*Event, bool) {
+	event, ok := ctx.Value(contextKey{}).(*Event)
+	return event, ok
+}
+
+// EventHandler handles a single type of event
+type EventHandler func(event *Event) error
+
+// Dispatcher routes verified events to the handler registered for them.
+// It must be wrapped by Middleware.
+type Dispatcher struct {
+	handlers map[string]EventHandler
+}
+
+// NewDispatcher returns a Dispatcher without any handlers
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{handlers: map[string]EventHandler{}}
+}
+
+// On registers the handler for an event, replacing any previous handler
+func (d *Dispatcher) On(name string, handler EventHandler) {
+	d.handlers[name] = handler
+}
+
+// ServeHTTP calls the handler for the event in the request context.
+// Events without a handler are acknowledged and otherwise ignored.
+func (d *Dispatcher) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	event, ok := FromContext(r.Context())
+	if !ok {
+		http.Error(w, "Webhook has not been verified", http.StatusUnauthorized)
+		return
+	}
+
+	if handler, ok := d.handlers[event.Name]; ok {
+		if err := handler(event); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,126 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/netlify/netlify-go"
+)
+
+const testSecret = "my-secret"
+
+func sign(t *testing.T, secret, body string) string {
+	return signClaims(t, secret, "HS256", &signatureClaims{Issuer: "netlify", Sha256: bodySha(body)})
+}
+
+func signClaims(t *testing.T, secret, alg string, claims *signatureClaims) string {
+	header, err := json.Marshal(&signatureHeader{Alg: alg})
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func newRequest(body, event, signature string) *http.Request {
+	r := httptest.NewRequest("POST", "/hook", strings.NewReader(body))
+	r.Header.Set(EventHeader, event)
+	r.Header.Set(SignatureHeader, signature)
+	return r
+}
+
+func TestMiddleware_Dispatch(t *testing.T) {
+	body := `{"id":"my-deploy","state":"error","error_message":"Build script returned non-zero exit code"}`
+
+	var received *Event
+	dispatcher := NewDispatcher()
+	dispatcher.On(netlify.HookEventDeployFailed, func(event *Event) error {
+		received = event
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	Middleware(testSecret, dispatcher).ServeHTTP(w, newRequest(body, netlify.HookEventDeployFailed, sign(t, testSecret, body)))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %v: %v", w.Code, w.Body.String())
+	}
+	if received == nil || received.Deploy.Id != "my-deploy" || received.Deploy.State != "error" {
+		t.Errorf("Expected deploy_failed handler to receive my-deploy, got %v", received)
+	}
+}
+
+func TestMiddleware_Invalid_Signature(t *testing.T) {
+	body := `{"id":"my-deploy"}`
+	unsigned := sign(t, testSecret, body)
+	unsigned = unsigned[:strings.LastIndex(unsigned, ".")+1]
+
+	cases := map[string]string{
+		"missing":       "",
+		"wrong secret":  sign(t, "other-secret", body),
+		"modified body": sign(t, testSecret, `{"id":"other-deploy"}`),
+		"malformed":     "not-a-jws",
+		"unsigned":      unsigned,
+		"wrong issuer":  signClaims(t, testSecret, "HS256", &signatureClaims{Issuer: "other", Sha256: bodySha(body)}),
+		"alg none":      signClaims(t, testSecret, "none", &signatureClaims{Issuer: "netlify", Sha256: bodySha(body)}),
+		"expired": signClaims(t, testSecret, "HS256", &signatureClaims{
+			Issuer: "netlify", Sha256: bodySha(body), ExpiresAt: time.Now().Add(-time.Minute).Unix(),
+		}),
+	}
+
+	for name, signature := range cases {
+		called := false
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { called = true })
+
+		w := httptest.NewRecorder()
+		Middleware(testSecret, next).ServeHTTP(w, newRequest(body, netlify.HookEventDeployCreated, signature))
+
+		if w.Code != http.StatusUnauthorized || called {
+			t.Errorf("%s signature: expected request to be rejected, got status %v", name, w.Code)
+		}
+	}
+}
+
+func bodySha(body string) string {
+	sum := sha256.Sum256([]byte(body))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestVerify_Token(t *testing.T) {
+	// Signed outside of this package with the secret "my-secret"
+	signature := "eyJhbGciOiAiSFMyNTYiLCAidHlwIjogIkpXVCJ9." +
+		"eyJpc3MiOiAibmV0bGlmeSIsICJzaGEyNTYiOiAiMmIxYmM1MTYwMjE2MGUwNDY5YTU3NGE2OTk5ZWNlY2ZiYjc0NzQ0MTU5YzQ1MWZkYmYyNjgzYzk2ZDQ0YWEyMSJ9." +
+		"s2x9shZjbddirO0qSfNNhs8IQQI9uFHA3SX3Rs6ENTE"
+
+	if err := Verify(testSecret, signature, []byte(`{"id":"my-deploy"}`)); err != nil {
+		t.Errorf("Verify returned an error: %v", err)
+	}
+}
+
+func TestMiddleware_Body_Too_Large(t *testing.T) {
+	called := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { called = true })
+
+	body := strings.Repeat("a", MaxBodySize+1)
+	w := httptest.NewRecorder()
+	Middleware(testSecret, next).ServeHTTP(w, newRequest(body, netlify.HookEventDeployCreated, sign(t, testSecret, body)))
+
+	if w.Code != http.StatusRequestEntityTooLarge || called {
+		t.Errorf("Expected a large body to be rejected, got status %v", w.Code)
+	}
+}